@@ -0,0 +1,6 @@
+//go:build !config_noautoload
+
+package config
+
+// 是否在 init 时自动查找并加载配置文件（默认开启；使用 -tags config_noautoload 构建可关闭，见 noautoload.go）
+const autoLoad = true
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+// 清空迁移/废弃警告的去重记录
+func resetWarnOnce(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		deprecationWarnMu.Lock()
+		deprecationWarned = make(map[string]bool)
+		deprecationWarnMu.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestCachedHostPortSections(t *testing.T) {
+	tests := []struct {
+		name, content string
+		host          string
+		port          int
+		migrated      bool
+	}{
+		{"规定的节", "[server]\nhost = 10.0.0.1\n[app]\nport = 8080\n", "10.0.0.1", 8080, false},
+		{"host 误写在 [app]", "[app]\nhost = 10.0.0.2\n", "10.0.0.2", 50100, true},
+		{"port 误写在 [server]", "[server]\nport = 9090\n", "0.0.0.0", 9090, true},
+		{"两个节都有时以规定的节为准", "[server]\nhost = right\nport = 1\n[app]\nhost = wrong\nport = 2\n", "right", 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetWarnOnce(t)
+			log := useRecordingLogger(t)
+			loadTestConfig(t, tt.content)
+
+			if APP_HOST != tt.host || APP_PORT != tt.port {
+				t.Errorf("APP_HOST, APP_PORT = %q, %d, want %q, %d", APP_HOST, APP_PORT, tt.host, tt.port)
+			}
+			if got := log.has("WARN 配置项放错了节，请迁移"); got != tt.migrated {
+				t.Errorf("迁移警告 = %v, want %v", got, tt.migrated)
+			}
+		})
+	}
+}
+
+func TestCachedPortFallbackAfterReload(t *testing.T) {
+	resetWarnOnce(t)
+	log := useRecordingLogger(t)
+	path := loadTestConfig(t, "[app]\nport = 8080\n")
+
+	if err := os.WriteFile(path, []byte("[server]\nport = 9090\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if APP_PORT != 9090 {
+		t.Errorf("Reload 后 APP_PORT = %d, want 9090", APP_PORT)
+	}
+
+	log.messages = nil
+	if err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if log.has("WARN 配置项放错了节，请迁移") {
+		t.Error("同一配置项的迁移警告只应记录一次")
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// 配置生成命令的执行超时
+const commandLoadTimeout = 30 * time.Second
+
+// LoadFromCommand 执行外部命令，将其标准输出作为配置内容解析并替换当前文件配置，
+// 适合由辅助脚本在启动时生成配置（如解密 Vault 中保存的配置文件）
+// 输出以 { 开头时按 JSON 解析，其余按 INI 解析；命令超过 30 秒未结束会被终止
+// 命令无法启动、超时、以非零状态码退出或输出无法解析时返回错误（包含标准错误输出），当前配置保持不变
+// 输出中以 @ 开头的值按普通值处理，不解析为文件引用（见 resolveFileRefs）
+// 配置生效后 ConfigFilePath 为空，之后调用 Reload 会重新加载本地配置文件
+func LoadFromCommand(name string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), commandLoadTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("执行超时（%s）", commandLoadTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("执行配置命令 %s 失败: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("执行配置命令 %s 失败: %w", name, err)
+	}
+
+	result, err := parseConfigData(stdout.Bytes())
+	if err != nil {
+		err = fmt.Errorf("解析配置命令 %s 的输出失败: %w", name, err)
+		logLoadEvent(name, nil, err)
+		return err
+	}
+
+	err = commitWithLock(func() error {
+		return commitValidatedLocked("", result)
+	})
+	logLoadEvent(name, result, err)
+	return err
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GetCORSOrigins 读取逗号分隔的 CORS 允许来源列表（如 allowed_origins = https://a.com, https://b.com:8443）
+// 每一项必须是只有协议（http/https）和主机（可带端口）的 origin，不能带路径、查询参数或用户信息；
+// 末尾单独的 / 会被去掉，协议与主机统一转为小写，便于与请求头 Origin 直接比较
+// 特殊项 * 表示允许任意来源，原样保留；无效项打印警告后丢弃；键不存在时返回 nil
+func GetCORSOrigins(section, key string) []string {
+	items := getStringSliceConfig(section, key, nil)
+	if items == nil {
+		return nil
+	}
+
+	origins := make([]string, 0, len(items))
+	for _, item := range items {
+		if item == "*" {
+			origins = append(origins, item)
+			continue
+		}
+		origin, err := normalizeOrigin(item)
+		if err != nil {
+			fmt.Printf("警告：配置项 [%s] %s 中的来源 %q 无效，已忽略: %v\n", section, key, item, err)
+			continue
+		}
+		origins = append(origins, origin)
+	}
+	return origins
+}
+
+// 校验并规范化 origin：scheme://host[:port]
+func normalizeOrigin(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	scheme := strings.ToLower(u.Scheme)
+	switch {
+	case scheme != "http" && scheme != "https":
+		return "", errors.New("协议必须是 http 或 https")
+	case u.Host == "" || u.Hostname() == "":
+		return "", errors.New("缺少主机名")
+	case u.User != nil:
+		return "", errors.New("不能包含用户信息")
+	case u.Path != "" && u.Path != "/":
+		return "", errors.New("不能包含路径")
+	case u.RawQuery != "" || u.Fragment != "" || u.ForceQuery:
+		return "", errors.New("不能包含查询参数或片段")
+	}
+	return scheme + "://" + strings.ToLower(u.Host), nil
+}
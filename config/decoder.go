@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 按类型名注册的值解码器
+var (
+	decoderMu sync.RWMutex
+	decoders  = map[string]func(string) (interface{}, error){
+		"int": func(s string) (interface{}, error) {
+			return strconv.Atoi(stripThousandsSeparators(strings.TrimSpace(s)))
+		},
+		"bool": func(s string) (interface{}, error) {
+			b, ok := parseBoolValue(s)
+			if !ok {
+				return nil, fmt.Errorf("无法识别的布尔值 %q", s)
+			}
+			return b, nil
+		},
+		"float": func(s string) (interface{}, error) {
+			return parseFloatValue(s)
+		},
+		"duration": func(s string) (interface{}, error) {
+			return time.ParseDuration(strings.TrimSpace(s))
+		},
+	}
+)
+
+// RegisterDecoder 按类型名注册值解码器，供 GetDecoded 使用，便于在不修改本包的情况下支持自定义类型（如颜色、坐标）
+// 内置 int、bool、float、duration 四种解码器（规则分别同 getIntConfig、getBoolConfig、getFloatConfig、time.ParseDuration），
+// 同名注册会覆盖已有的解码器（包括内置的）
+func RegisterDecoder(typeName string, fn func(string) (interface{}, error)) {
+	decoderMu.Lock()
+	defer decoderMu.Unlock()
+	decoders[typeName] = fn
+}
+
+// GetDecoded 读取配置并用 typeName 对应的解码器解码，返回解码结果
+// 类型名未注册时返回错误；键不存在时返回 ErrMissingKey；解码失败时返回解码器的错误
+func GetDecoded(section, key, typeName string) (interface{}, error) {
+	decoderMu.RLock()
+	decode, registered := decoders[typeName]
+	decoderMu.RUnlock()
+	if !registered {
+		return nil, fmt.Errorf("未注册的配置值类型 %q", typeName)
+	}
+
+	value, exists := lookup(section, key)
+	if !exists {
+		return nil, fmt.Errorf("配置项 [%s] %s: %w", section, key, ErrMissingKey)
+	}
+
+	decoded, err := decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("配置项 [%s] %s 无法解码为 %s: %w", section, key, typeName, err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 代码中声明的默认配置（见 LoadDefaults），位于内嵌默认配置之下，是最底层的一层
+var codeDefaults *parseResult
+
+// LoadDefaults 是 Unmarshal 的反向操作：读取结构体 v 的字段，将其默认值登记为配置的最底层
+// 键名规则与 Unmarshal 相同（config 标签、section:xxx 嵌套节、config:"-" 跳过）
+// 每个字段的默认值取 default 标签；没有标签时取字段当前的非零值，零值字段不登记
+// 配置文件、内嵌默认配置、环境变量等已有的值不会被覆盖，只补齐缺失的键，
+// 使 GetAll、Dump 等能展示完整的配置项；多次调用时先登记的默认值优先
+//
+//	config.LoadDefaults("app", &AppConfig{Port: 50100})
+func LoadDefaults(section string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New("LoadDefaults 需要结构体或非空的结构体指针")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errors.New("LoadDefaults 需要结构体或非空的结构体指针")
+	}
+
+	result := &parseResult{
+		sections: make(map[string]map[string]string),
+		order:    make(map[string][]string),
+	}
+	if err := collectDefaults(section, rv, result); err != nil {
+		return err
+	}
+
+	return commitWithLock(func() error {
+		if codeDefaults == nil {
+			codeDefaults = result
+		} else {
+			codeDefaults = mergeParseResults(result, codeDefaults)
+		}
+		commitLocked(configFilePath, fileResult)
+		return nil
+	})
+}
+
+// 收集结构体各字段的默认值，section:xxx 标签的字段递归收集对应的节
+func collectDefaults(section string, rv reflect.Value, result *parseResult) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("config")
+		if key == "-" {
+			continue
+		}
+		if sub, ok := strings.CutPrefix(key, "section:"); ok {
+			fv := rv.Field(i)
+			if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+				if fv.IsNil() {
+					fv = reflect.New(fv.Type().Elem()) // nil 指针按零值结构体处理，只收集 default 标签
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() != reflect.Struct {
+				return fmt.Errorf("字段 %s: section 标签只能用于结构体或结构体指针字段，实际为 %s", field.Name, fv.Type())
+			}
+			if err := collectDefaults(sub, fv, result); err != nil {
+				return fmt.Errorf("字段 %s: %w", field.Name, err)
+			}
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+
+		value, ok := field.Tag.Lookup("default")
+		if !ok {
+			if rv.Field(i).IsZero() {
+				continue
+			}
+			var err error
+			if value, err = formatFieldValue(rv.Field(i)); err != nil {
+				return fmt.Errorf("字段 %s 无法作为配置项 [%s] %s 的默认值: %w", field.Name, section, key, err)
+			}
+		}
+
+		if result.sections[section] == nil {
+			result.sections[section] = make(map[string]string)
+		}
+		if _, exists := result.sections[section][key]; !exists {
+			result.order[section] = append(result.order[section], key)
+		}
+		result.sections[section][key] = value
+	}
+	return nil
+}
+
+// 将字段值格式化为配置字符串，支持的类型与 setFieldValue 相同
+func formatFieldValue(field reflect.Value) (string, error) {
+	if field.Type() == durationType {
+		return time.Duration(field.Int()).String(), nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'g', -1, field.Type().Bits()), nil
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.String {
+			items := make([]string, field.Len())
+			for i := range items {
+				items[i] = field.Index(i).String()
+			}
+			return strings.Join(items, ", "), nil
+		}
+	}
+	return "", fmt.Errorf("不支持的字段类型 %s", field.Type())
+}
+
+// 所有默认配置层合并后的结果：内嵌默认配置覆盖代码默认值；均未设置时返回 nil（调用方需持有 configMu）
+func defaultsLocked() *parseResult {
+	switch {
+	case codeDefaults == nil:
+		return embeddedDefault
+	case embeddedDefault == nil:
+		return codeDefaults
+	}
+	return mergeParseResults(codeDefaults, embeddedDefault)
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"sort"
+	"sync"
+)
+
+// KeyChange 单个配置项的变化（新增时 Old 为空，删除时 New 为空）
+type KeyChange struct {
+	Section string
+	Key     string
+	Old     string
+	New     string
+}
+
+// ConfigDelta 两份配置之间的差异，各列表按节名、键名排序
+type ConfigDelta struct {
+	Added    []KeyChange // 新出现的键
+	Removed  []KeyChange // 不再存在的键
+	Modified []KeyChange // 值发生变化的键
+}
+
+// Empty 判断是否没有任何变化
+func (d ConfigDelta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// Changed 判断指定配置项是否有变化（新增、删除或修改），如只在 [db] host 变化时重建连接池：
+//
+//	if delta.Changed("db", "host") { ... }
+func (d ConfigDelta) Changed(section, key string) bool {
+	for _, list := range [][]KeyChange{d.Added, d.Removed, d.Modified} {
+		for _, c := range list {
+			if c.Section == section && c.Key == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Diff 比较两份配置实例的原始值，返回 old → new 的差异；nil 视为空配置
+func Diff(old, new *Config) ConfigDelta {
+	var oldSections, newSections map[string]map[string]string
+	if old != nil {
+		oldSections = old.sections
+	}
+	if new != nil {
+		newSections = new.sections
+	}
+	return diffSections(oldSections, newSections)
+}
+
+// 逐节比较两份配置的原始值
+func diffSections(old, new map[string]map[string]string) ConfigDelta {
+	var delta ConfigDelta
+	for section, values := range new {
+		for key, value := range values {
+			oldValue, exists := old[section][key]
+			switch {
+			case !exists:
+				delta.Added = append(delta.Added, KeyChange{Section: section, Key: key, New: value})
+			case oldValue != value:
+				delta.Modified = append(delta.Modified, KeyChange{Section: section, Key: key, Old: oldValue, New: value})
+			}
+		}
+	}
+	for section, values := range old {
+		for key, value := range values {
+			if _, exists := new[section][key]; !exists {
+				delta.Removed = append(delta.Removed, KeyChange{Section: section, Key: key, Old: value})
+			}
+		}
+	}
+
+	for _, list := range [][]KeyChange{delta.Added, delta.Removed, delta.Modified} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Section != list[j].Section {
+				return list[i].Section < list[j].Section
+			}
+			return list[i].Key < list[j].Key
+		})
+	}
+	return delta
+}
+
+// 变更回调（见 OnChange）
+var (
+	changeMu        sync.Mutex
+	changeListeners = make(map[int]*changeListener)
+	nextListenerID  int
+)
+
+// 单个变更回调及其待处理的差异队列，由专属的 goroutine 按提交顺序逐个处理
+type changeListener struct {
+	fn      func(ConfigDelta)
+	mu      sync.Mutex
+	pending []ConfigDelta
+	wake    chan struct{} // 有新差异入队（带 1 个缓冲）
+	done    chan struct{} // 已取消注册
+}
+
+// OnChange 注册配置变更回调：每次成功的 Load/Reload/Set 等改变了已加载配置时，以本次的差异调用 fn
+// 差异比较的是配置文件与默认配置合并后的原始值，不含环境变量、命令行参数与覆盖层；没有实际变化时不调用
+// 每个回调有一个专属的 goroutine（因此可以在回调中读取配置），按变更提交的顺序逐个调用，不会并发执行，
+// 可以放心地按到达顺序应用差异；回调处理较慢时差异在队列中排队，不会阻塞重新加载
+// 返回的函数用于取消注册（尚未处理的差异被丢弃），可重复调用
+func OnChange(fn func(ConfigDelta)) (cancel func()) {
+	l := &changeListener{fn: fn, wake: make(chan struct{}, 1), done: make(chan struct{})}
+	go l.run()
+
+	changeMu.Lock()
+	defer changeMu.Unlock()
+	id := nextListenerID
+	nextListenerID++
+	changeListeners[id] = l
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			changeMu.Lock()
+			delete(changeListeners, id)
+			changeMu.Unlock()
+			close(l.done)
+		})
+	}
+}
+
+// 将差异加入所有变更回调的队列（调用方需持有 configMu，保证入队顺序与提交顺序一致）
+func notifyChangeListeners(delta ConfigDelta) {
+	if delta.Empty() {
+		return
+	}
+
+	changeMu.Lock()
+	defer changeMu.Unlock()
+	for _, l := range changeListeners {
+		l.mu.Lock()
+		l.pending = append(l.pending, delta)
+		l.mu.Unlock()
+		select {
+		case l.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// 按顺序处理队列中的差异，直到取消注册
+func (l *changeListener) run() {
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-l.wake:
+		}
+
+		l.mu.Lock()
+		batch := l.pending
+		l.pending = nil
+		l.mu.Unlock()
+		for _, delta := range batch {
+			select {
+			case <-l.done:
+				return
+			default:
+			}
+			l.fn(delta)
+		}
+	}
+}
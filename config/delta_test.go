@@ -0,0 +1,68 @@
+package config
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	old := &Config{sections: map[string]map[string]string{"db": {"host": "a", "port": "1"}}}
+	new := &Config{sections: map[string]map[string]string{"db": {"host": "b", "user": "u"}}}
+
+	delta := Diff(old, new)
+	if len(delta.Added) != 1 || delta.Added[0] != (KeyChange{Section: "db", Key: "user", New: "u"}) {
+		t.Errorf("Added = %+v", delta.Added)
+	}
+	if len(delta.Removed) != 1 || delta.Removed[0] != (KeyChange{Section: "db", Key: "port", Old: "1"}) {
+		t.Errorf("Removed = %+v", delta.Removed)
+	}
+	if len(delta.Modified) != 1 || delta.Modified[0] != (KeyChange{Section: "db", Key: "host", Old: "a", New: "b"}) {
+		t.Errorf("Modified = %+v", delta.Modified)
+	}
+	if !delta.Changed("db", "host") || delta.Changed("db", "name") {
+		t.Error("Changed 结果不正确")
+	}
+	if !Diff(old, old).Empty() {
+		t.Error("相同配置的差异应为空")
+	}
+}
+
+func TestOnChangeDeliversInOrder(t *testing.T) {
+	loadTestConfig(t, "[db]\nhost = a\n")
+
+	const n = 200
+	got := make(chan ConfigDelta, n)
+	cancel := OnChange(func(d ConfigDelta) { got <- d })
+	defer cancel()
+
+	for i := 1; i <= n; i++ {
+		Set("db", "host", strconv.Itoa(i))
+	}
+	for i := 1; i <= n; i++ {
+		select {
+		case d := <-got:
+			if len(d.Modified) != 1 || d.Modified[0].New != strconv.Itoa(i) || d.Modified[0].Old != strconv.Itoa(i-1) && i > 1 {
+				t.Fatalf("第 %d 个差异 = %+v", i, d)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("等待第 %d 个差异超时", i)
+		}
+	}
+}
+
+func TestOnChangeCancel(t *testing.T) {
+	loadTestConfig(t, "[db]\nhost = a\n")
+
+	got := make(chan ConfigDelta, 1)
+	cancel := OnChange(func(d ConfigDelta) { got <- d })
+	cancel()
+	cancel() // 重复调用无副作用
+
+	Set("db", "host", "b")
+	select {
+	case d := <-got:
+		t.Fatalf("取消注册后仍收到差异 %+v", d)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+)
+
+// 键重命名记录（读写均需持有 configMu）
+var (
+	deprecatedKeys = make(map[string]map[string]string) // section → 旧键 → 新键
+	renamedKeys    = make(map[string]map[string]string) // section → 新键 → 旧键
+)
+
+// 跨节的键别名：新位置 (section, key) → 旧位置 (section, key)（读写均需持有 configMu，见 SetAliases）
+var keyAliases map[[2]string][2]string
+
+// 已打印过废弃警告的键（每个键只警告一次）
+var (
+	deprecationWarnMu sync.Mutex
+	deprecationWarned = make(map[string]bool)
+)
+
+// DeprecateKey 声明配置键已重命名（如 [server] log_dir → log_path），便于平滑迁移
+// 读取新键时若新键不存在，则回退使用旧键的值；两者同时存在时新键优先
+// 旧键被读取、被回退使用或在当前配置文件中存在时打印废弃警告（每个键只警告一次）
+func DeprecateKey(section, oldKey, newKey string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if deprecatedKeys[section] == nil {
+		deprecatedKeys[section] = make(map[string]string)
+	}
+	if renamedKeys[section] == nil {
+		renamedKeys[section] = make(map[string]string)
+	}
+	deprecatedKeys[section][oldKey] = newKey
+	renamedKeys[section][newKey] = oldKey
+
+	if _, present := lookupFile(section, oldKey); present {
+		warnDeprecatedKey(section, oldKey, newKey)
+	}
+}
+
+// SetAliases 批量声明配置项的迁移关系：旧 (section, key) → 新 (section, key)，可跨节，适合大规模调整配置结构
+// 读取新位置时若新位置不存在，则透明地回退使用旧位置的值；两者同时存在时新位置优先
+// 发生回退或旧位置在当前配置文件中存在时打印废弃警告，每个旧位置只警告一次
+// 每次调用整体替换之前设置的别名（传入 nil 清空）；同一节内的单个重命名也可以使用 DeprecateKey
+//
+//	config.SetAliases(map[[2]string][2]string{
+//		{"server", "log_dir"}: {"logging", "dir"},
+//	})
+func SetAliases(aliases map[[2]string][2]string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	keyAliases = make(map[[2]string][2]string, len(aliases))
+	for oldLoc, newLoc := range aliases {
+		keyAliases[newLoc] = oldLoc
+		if _, present := lookupFile(oldLoc[0], oldLoc[1]); present {
+			warnAliasedKey(oldLoc, newLoc)
+		}
+	}
+}
+
+// 打印跨节别名的废弃警告（同一个旧位置只打印一次）
+func warnAliasedKey(oldLoc, newLoc [2]string) {
+	warnOnce(oldLoc[0], oldLoc[1], func() {
+		fmt.Printf("警告：配置项 [%s] %s 已废弃，请改用 [%s] %s\n", oldLoc[0], oldLoc[1], newLoc[0], newLoc[1])
+	})
+}
+
+// 打印废弃键警告（同一个键只打印一次）
+func warnDeprecatedKey(section, oldKey, newKey string) {
+	warnOnce(section, oldKey, func() {
+		fmt.Printf("警告：配置项 [%s] %s 已废弃，请改用 %s\n", section, oldKey, newKey)
+	})
+}
+
+// 每个旧键只执行一次 warn
+func warnOnce(section, oldKey string, warn func()) {
+	id := section + "\x00" + oldKey
+
+	deprecationWarnMu.Lock()
+	defer deprecationWarnMu.Unlock()
+	if deprecationWarned[id] {
+		return
+	}
+	deprecationWarned[id] = true
+	warn()
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// GetDSN 由节中的独立配置项拼装数据库连接串（URL 形式，如 postgres://user:pass@db:5432/app?sslmode=disable）
+// 读取的键：
+//   - driver    URL scheme，默认 postgres
+//   - host      主机名（必填）
+//   - port      端口（可选）
+//   - user      用户名（可选）
+//   - password  密码（可选，仅在设置了 user 时使用，按 URL 规则编码）
+//   - dbname    数据库名（必填）
+//   - sslmode   追加为查询参数（可选）
+//
+// 必填项缺失时打印警告（只列出缺失的键名，不会输出拼装结果或密码）并返回空字符串
+func GetDSN(section string) string {
+	host := strings.TrimSpace(getStringConfig(section, "host", ""))
+	dbname := strings.TrimSpace(getStringConfig(section, "dbname", ""))
+
+	var missing []string
+	if host == "" {
+		missing = append(missing, "host")
+	}
+	if dbname == "" {
+		missing = append(missing, "dbname")
+	}
+	if len(missing) > 0 {
+		fmt.Printf("警告：节 [%s] 缺少数据库连接配置 %s，无法生成连接串\n", section, strings.Join(missing, "、"))
+		return ""
+	}
+
+	u := url.URL{
+		Scheme: strings.TrimSpace(getStringConfig(section, "driver", "postgres")),
+		Host:   host,
+		Path:   "/" + dbname,
+	}
+	if port := strings.TrimSpace(getStringConfig(section, "port", "")); port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	}
+	if user := getStringConfig(section, "user", ""); user != "" {
+		if password, exists := lookup(section, "password"); exists {
+			u.User = url.UserPassword(user, password)
+		} else {
+			u.User = url.User(user)
+		}
+	}
+	if sslmode := strings.TrimSpace(getStringConfig(section, "sslmode", "")); sslmode != "" {
+		u.RawQuery = url.Values{"sslmode": {sslmode}}.Encode()
+	}
+	return u.String()
+}
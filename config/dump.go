@@ -0,0 +1,44 @@
+package config
+
+import "encoding/json"
+
+// Flatten 将全部配置展开为 section.key → value 的扁平映射，便于对接不理解节概念的库
+// 键集合取自已加载的配置文件，值按 GetConfig 的优先级解析（已包含命令行参数与环境变量覆盖）
+// 返回的是副本，修改不会影响当前配置
+func Flatten() map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	flat := make(map[string]string)
+	for section, values := range config {
+		for key := range values {
+			if value, exists := lookupLocked(section, key); exists {
+				flat[dottedKey(section, key)] = value
+			}
+		}
+	}
+	return flat
+}
+
+// GetAll 返回全部配置的 节 → 键 → 值 副本，用于展示或调试
+// 值按 GetConfig 的优先级解析，并按脱敏规则处理（见 MarkSectionSecret、SetRedactor）
+func GetAll() map[string]map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	all := make(map[string]map[string]string, len(config))
+	for section, values := range config {
+		all[section] = make(map[string]string, len(values))
+		for key := range values {
+			if value, exists := lookupLocked(section, key); exists {
+				all[section][key] = redactValue(section, key, value)
+			}
+		}
+	}
+	return all
+}
+
+// DumpJSON 以格式化 JSON 输出全部配置（脱敏规则同 GetAll）
+func DumpJSON() ([]byte, error) {
+	return json.MarshalIndent(GetAll(), "", "  ")
+}
@@ -0,0 +1,30 @@
+package config
+
+import "bytes"
+
+// SetEmbeddedDefault 设置内嵌的默认配置（通常由 main 包通过 go:embed 提供），使程序无需外部文件也能运行
+// 内嵌配置位于配置文件之下：磁盘上的配置文件存在时，其中的键覆盖内嵌默认值，Reload 后依然生效
+// 内嵌配置中以 @ 开头的值按普通值处理，不解析为文件引用（见 resolveFileRefs）
+// 包初始化时找不到配置文件的警告会推迟到第一次读取配置时输出，在此之前调用 SetEmbeddedDefault 则不再输出；
+// 设置后 APP_PORT 等常用配置变量会按新的配置重新赋值
+//
+//	//go:embed config.ini
+//	var defaultConfig []byte
+//
+//	func main() {
+//	    config.SetEmbeddedDefault(defaultConfig)
+//	}
+func SetEmbeddedDefault(data []byte) {
+	result, err := parseIni(bytes.NewReader(data))
+	if err != nil {
+		currentLogger().Warn("内嵌默认配置解析失败，已忽略", "error", err)
+		return
+	}
+
+	missingConfigWarning.Store(nil)
+	commitWithLock(func() error {
+		embeddedDefault = result
+		commitLocked(configFilePath, fileResult)
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+// 撤销内嵌默认配置并恢复为空配置
+func resetEmbeddedDefault() {
+	commitWithLock(func() error {
+		embeddedDefault = nil
+		return nil
+	})
+	resetTestConfig()
+}
+
+func TestSetEmbeddedDefaultRefreshesCachedVars(t *testing.T) {
+	resetTestConfig()
+	t.Cleanup(resetEmbeddedDefault)
+
+	SetEmbeddedDefault([]byte("[app]\nport = 9999\nname = embedded\n"))
+	if APP_PORT != 9999 {
+		t.Errorf("APP_PORT = %d, want 9999", APP_PORT)
+	}
+	if APP_NAME != "embedded" {
+		t.Errorf("APP_NAME = %q, want embedded", APP_NAME)
+	}
+
+	// 配置文件中的键覆盖内嵌默认值，Load 后常用配置变量同样刷新
+	loadTestConfig(t, "[app]\nport = 8080\n")
+	if APP_PORT != 8080 {
+		t.Errorf("Load 后 APP_PORT = %d, want 8080", APP_PORT)
+	}
+	if APP_NAME != "embedded" {
+		t.Errorf("Load 后 APP_NAME = %q, want embedded", APP_NAME)
+	}
+}
+
+func TestSetEmbeddedDefaultClearsMissingConfigWarning(t *testing.T) {
+	t.Cleanup(func() { missingConfigWarning.Store(nil) })
+	t.Cleanup(resetEmbeddedDefault)
+
+	err := errors.New("未找到配置文件")
+	missingConfigWarning.Store(&err)
+	SetEmbeddedDefault([]byte("[app]\nport = 9999\n"))
+	if missingConfigWarning.Load() != nil {
+		t.Error("设置内嵌默认配置后仍有待输出的未找到配置文件警告")
+	}
+}
+
+func TestMissingConfigWarningFlushedOnFirstRead(t *testing.T) {
+	t.Cleanup(func() { missingConfigWarning.Store(nil) })
+
+	err := errors.New("未找到配置文件")
+	missingConfigWarning.Store(&err)
+	GetConfig("app", "name", "")
+	if missingConfigWarning.Load() != nil {
+		t.Error("第一次读取配置后警告仍未输出")
+	}
+}
+
+func TestSetEmbeddedDefaultParseErrorUsesLogger(t *testing.T) {
+	log := useRecordingLogger(t)
+	t.Cleanup(resetEmbeddedDefault)
+
+	SetEmbeddedDefault([]byte("[app]\ncert = <<END\nunterminated\n"))
+	if !log.has("WARN 内嵌默认配置解析失败，已忽略") {
+		t.Errorf("内嵌默认配置解析失败应通过 Logger 警告，实际: %v", log.messages)
+	}
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// LoadEncrypted 读取经 AES-GCM 加密的配置文件（如 config.ini.enc），解密后解析并替换当前文件配置
+// 密钥长度须为 16、24 或 32 字节（AES-128/192/256），可从环境变量读取后传入；文件格式见 WriteEncrypted
+// 明文以 { 开头时按 JSON 解析，其余按 INI 解析；密钥错误或内容损坏时返回明确的错误，当前配置保持不变
+// 值中的 @file 引用基于加密文件所在目录解析（见 resolveFileRefs）
+// 配置生效后 ConfigFilePath 为空，之后调用 Reload 会重新加载本地明文配置文件
+func LoadEncrypted(path string, key []byte) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := decryptConfig(data, key)
+	if err != nil {
+		return fmt.Errorf("解密配置文件 %s 失败: %w", path, err)
+	}
+
+	result, err := parseConfigData(plaintext)
+	if err != nil {
+		err = fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+		logLoadEvent(path, nil, err)
+		return err
+	}
+	if err := resolveFileRefs(result, path); err != nil {
+		err = fmt.Errorf("%s: %w", path, err)
+		logLoadEvent(path, nil, err)
+		return err
+	}
+
+	err = commitWithLock(func() error {
+		return commitValidatedLocked("", result)
+	})
+	logLoadEvent(path, result, err)
+	return err
+}
+
+// WriteEncrypted 用 AES-GCM 加密配置内容并写入文件（权限 0600），供 LoadEncrypted 读取
+// 文件内容为 随机 nonce + 密文，每次写入使用新的 nonce
+func WriteEncrypted(path string, plaintext, key []byte) error {
+	gcm, err := newConfigGCM(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	return os.WriteFile(path, gcm.Seal(nonce, nonce, plaintext, nil), 0o600)
+}
+
+// 解密 nonce + 密文格式的配置内容
+func decryptConfig(data, key []byte) ([]byte, error) {
+	gcm, err := newConfigGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, errors.New("密文过短，文件可能已损坏")
+	}
+
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("密钥错误或内容已损坏")
+	}
+	return plaintext, nil
+}
+
+// 创建 AES-GCM 实例
+func newConfigGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("密钥长度必须为 16、24 或 32 字节: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// 显式绑定的环境变量：(section, key) → 环境变量名（读写均需持有 configMu）
+var envBindings = make(map[[2]string]string)
+
+// 允许被环境变量覆盖的配置项（nil 表示全部允许，读写均需持有 configMu）
+var envAllowlist map[[2]string]bool
+
+// SetEnvAllowlist 限制只有列出的 (section, key) 可以被环境变量覆盖，其余配置项忽略环境变量
+// 用于防止不可信的环境变量篡改敏感配置；同时作用于约定的 APP_ 变量和 BindEnv 绑定的变量
+// 传入 nil 恢复默认的全部允许；传入空切片（非 nil）则禁用所有环境变量覆盖（本包没有单独的全局开关）
+func SetEnvAllowlist(keys [][2]string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if keys == nil {
+		envAllowlist = nil
+		return
+	}
+	envAllowlist = make(map[[2]string]bool, len(keys))
+	for _, k := range keys {
+		envAllowlist[k] = true
+	}
+}
+
+// BindEnv 将配置项绑定到任意名称的环境变量（如把 [server] port 绑定到 PORT，兼容 Heroku 等平台约定）
+// 绑定的环境变量优先于约定的 APP_{SECTION}_{KEY}；两者都未设置时继续查找配置来源
+func BindEnv(section, key, envVar string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	envBindings[[2]string{section, key}] = envVar
+}
+
+// 查找配置项对应的环境变量（调用方需持有 configMu 读锁），不在允许列表中的配置项直接跳过，查找顺序为：
+//  1. BindEnv 绑定的变量名
+//  2. 全大写的 APP_{SECTION}_{KEY}
+//  3. 保留原始大小写的 APP_{section}_{key}（与 2 相同时跳过）
+func lookupEnvLocked(section, key string) (string, bool) {
+	if envAllowlist != nil && !envAllowlist[[2]string{section, key}] {
+		return "", false
+	}
+	if envVar, bound := envBindings[[2]string{section, key}]; bound {
+		if value, exists := os.LookupEnv(envVar); exists {
+			return value, true
+		}
+	}
+
+	upperKey := envKeyFor(section, key)
+	if value, exists := os.LookupEnv(upperKey); exists {
+		return value, true
+	}
+	if exactKey := exactEnvKeyFor(section, key); exactKey != upperKey {
+		return os.LookupEnv(exactKey)
+	}
+	return "", false
+}
+
+// LookupBoolEnv 只从环境变量读取布尔配置（查找规则同 lookupEnvLocked，解析规则同 getBoolConfig），
+// 忽略配置文件等其他来源，便于调用方显式地把环境变量叠加在文件配置之上：
+// 环境变量未设置时 set 为 false；值无法识别时打印警告，同样视为未设置
+func LookupBoolEnv(section, key string) (value bool, set bool) {
+	configMu.RLock()
+	raw, exists := lookupEnvLocked(section, key)
+	configMu.RUnlock()
+	if !exists {
+		return false, false
+	}
+
+	value, ok := parseBoolValue(raw)
+	if !ok {
+		fmt.Printf("警告：配置项 [%s] %s 的环境变量值 %q 不是有效的布尔值，视为未设置\n", section, key, raw)
+		return false, false
+	}
+	return value, true
+}
@@ -0,0 +1,76 @@
+package config
+
+import "testing"
+
+func TestEnvUppercaseBeforeExactCase(t *testing.T) {
+	loadTestConfig(t, "[myApp]\nlogLevel = file\n")
+
+	t.Setenv("APP_MYAPP_LOGLEVEL", "upper")
+	t.Setenv("APP_myApp_logLevel", "exact")
+	if got := mustLookup(t, "myApp", "logLevel"); got != "upper" {
+		t.Errorf("两种写法都设置时应优先全大写变量，实际 %q", got)
+	}
+}
+
+func TestEnvExactCaseFallback(t *testing.T) {
+	loadTestConfig(t, "[myApp]\nlogLevel = file\n")
+
+	t.Setenv("APP_myApp_logLevel", "exact")
+	if got := mustLookup(t, "myApp", "logLevel"); got != "exact" {
+		t.Errorf("只设置原始大小写变量时应使用它，实际 %q", got)
+	}
+}
+
+func TestEnvAllowlist(t *testing.T) {
+	loadTestConfig(t, "[db]\nhost = file-host\npassword = file-password\n")
+	SetEnvAllowlist([][2]string{{"db", "host"}})
+	t.Cleanup(func() { SetEnvAllowlist(nil) })
+
+	t.Setenv("APP_DB_HOST", "env-host")
+	t.Setenv("APP_DB_PASSWORD", "env-password")
+	BindEnv("db", "password", "DB_PASSWORD")
+	t.Cleanup(func() {
+		configMu.Lock()
+		delete(envBindings, [2]string{"db", "password"})
+		configMu.Unlock()
+	})
+	t.Setenv("DB_PASSWORD", "bound-password")
+
+	if got := mustLookup(t, "db", "host"); got != "env-host" {
+		t.Errorf("允许列表中的配置项应被环境变量覆盖，实际 %q", got)
+	}
+	if got := mustLookup(t, "db", "password"); got != "file-password" {
+		t.Errorf("不在允许列表中的配置项应忽略环境变量（包括 BindEnv），实际 %q", got)
+	}
+
+	SetEnvAllowlist(nil)
+	if got := mustLookup(t, "db", "password"); got != "bound-password" {
+		t.Errorf("恢复全部允许后应使用绑定的环境变量，实际 %q", got)
+	}
+}
+
+func TestLookupBoolEnv(t *testing.T) {
+	loadTestConfig(t, "[feature]\nbeta = true\n")
+
+	tests := []struct {
+		name, env      string
+		setEnv         bool
+		wantValue, set bool
+	}{
+		{"设置为 true", "yes", true, true, true},
+		{"设置为 false", "0", true, false, true},
+		{"未设置", "", false, false, false}, // 配置文件中的 true 不参与
+		{"无法识别", "maybe", true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setEnv {
+				t.Setenv("APP_FEATURE_BETA", tt.env)
+			}
+			value, set := LookupBoolEnv("feature", "beta")
+			if value != tt.wantValue || set != tt.set {
+				t.Errorf("LookupBoolEnv = (%v, %v), want (%v, %v)", value, set, tt.wantValue, tt.set)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import "sort"
+
+// 只能从环境变量读取的节（读写均需持有 configMu）
+var envOnlySections = make(map[string]bool)
+
+// 已警告过的各仅环境变量节中被忽略的键数（读写均需持有 configMu），数量不变时不重复警告
+var envOnlyWarned = make(map[string]int)
+
+// MarkSectionEnvOnly 将节标记为仅从环境变量读取（如 [secrets]），确保密钥不会被提交到配置文件中
+// 该节的键只按环境变量（APP_{SECTION}_{KEY} 或 BindEnv 绑定的变量）解析，找不到时使用调用方的默认值；
+// 配置文件、内嵌默认配置、其他配置来源以及命令行参数中的值都被忽略，同时也不会暴露给配置模板
+// 配置文件中存在该节的键时记录警告（标记时以及之后加载的配置中被忽略的键数变化时）
+func MarkSectionEnvOnly(section string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	envOnlySections[section] = true
+	warnEnvOnlyEntriesLocked(fileResult, section)
+}
+
+// 提示配置文件中被忽略的仅环境变量节的键（调用方需持有 configMu）
+func warnEnvOnlyEntriesLocked(result *parseResult, sections ...string) {
+	if result == nil {
+		return
+	}
+	if len(sections) == 0 {
+		for section := range envOnlySections {
+			sections = append(sections, section)
+		}
+		sort.Strings(sections)
+	}
+
+	for _, section := range sections {
+		ignored := len(result.order[section])
+		if ignored == envOnlyWarned[section] {
+			continue // Set、回滚等重新提交同一份配置时不重复警告
+		}
+		envOnlyWarned[section] = ignored
+		if ignored > 0 {
+			currentLogger().Warn("节只能通过环境变量设置，配置文件中的键已被忽略", "section", section, "ignored", ignored)
+		}
+	}
+}
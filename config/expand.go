@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// 展开配置值中的环境变量引用：$NAME 或 ${NAME}，$$ 表示字面量 $
+// 用于让配置文件只描述结构、密钥留在环境变量中，如 api_key = $SECRET_API_KEY
+// ${NAME:-default} 与 shell 相同：环境变量未定义或为空时使用 :- 之后的字面量，如 host = ${HOST:-localhost}
+// 注意：这里只引用环境变量，不会引用其他配置键
+// 未定义的环境变量（且没有 :- 默认值）替换为空字符串并打印警告
+func expandEnvRefs(value string) string {
+	return expandRefs(value, lookupEnvRef)
+}
+
+// 展开 $NAME / ${NAME} / ${NAME:-default} 引用（$$ 表示字面量 $），引用的值由 resolve 提供，第二个返回值表示是否存在；
+// 不存在的引用替换为空字符串并打印警告；带默认值的引用在不存在或值为空时使用默认值（默认值原样写入，不能包含 }）
+// 替换结果不会被再次展开
+func expandRefs(value string, resolve func(name string) (string, bool)) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) {
+			sb.WriteByte(value[i])
+			continue
+		}
+
+		next := value[i+1]
+		switch {
+		case next == '$':
+			sb.WriteByte('$')
+			i++
+		case next == '{':
+			end := strings.IndexByte(value[i+2:], '}')
+			if end <= 0 {
+				// 未闭合或空的 ${}，按字面量保留
+				sb.WriteByte('$')
+				continue
+			}
+			name, defaultValue, hasDefault := strings.Cut(value[i+2:i+2+end], ":-")
+			if hasDefault {
+				if resolved, exists := resolve(name); exists && resolved != "" {
+					sb.WriteString(resolved)
+				} else {
+					sb.WriteString(defaultValue)
+				}
+			} else {
+				sb.WriteString(resolveRef(name, resolve))
+			}
+			i += 2 + end
+		case isEnvNameStart(next):
+			end := i + 2
+			for end < len(value) && isEnvNameChar(value[end]) {
+				end++
+			}
+			sb.WriteString(resolveRef(value[i+1:end], resolve))
+			i = end - 1
+		default:
+			sb.WriteByte('$')
+		}
+	}
+	return sb.String()
+}
+
+// EnvReferences 静态扫描已加载的配置值，返回每个配置项（键为 section.key）引用的环境变量名（$NAME / ${NAME} / ${NAME:-default}），
+// 已去重并排序，没有引用的配置项不出现在结果中；供安全审计查看哪些外部输入会流入配置
+// 模板值（{{ }}）按模板渲染而不展开环境变量引用，不在扫描范围内
+func EnvReferences() map[string][]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	refs := make(map[string][]string)
+	for section, values := range config {
+		if envOnlySections[section] {
+			continue
+		}
+		for key, value := range values {
+			if isTemplateValue(value) {
+				continue
+			}
+			var names []string
+			expandRefs(value, func(name string) (string, bool) {
+				if !containsString(names, name) {
+					names = append(names, name)
+				}
+				return "", true
+			})
+			if len(names) > 0 {
+				sort.Strings(names)
+				refs[dottedKey(section, key)] = names
+			}
+		}
+	}
+	return refs
+}
+
+// 解析没有默认值的引用，不存在时打印警告并返回空字符串
+func resolveRef(name string, resolve func(name string) (string, bool)) string {
+	value, exists := resolve(name)
+	if !exists {
+		fmt.Printf("警告：配置值引用的环境变量 %s 未定义，替换为空字符串\n", name)
+	}
+	return value
+}
+
+// 读取被引用的环境变量
+func lookupEnvRef(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+func isEnvNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isEnvNameChar(c byte) bool {
+	return isEnvNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// 处理来自配置文件/配置来源的原始值：模板值按模板渲染，其余值展开环境变量引用
+// 命令行参数和环境变量本身的值不做环境变量展开，避免误改其中的 $ 字符
+func processValue(value string) string {
+	if isTemplateValue(value) {
+		return renderValue(value)
+	}
+	return expandEnvRefs(value)
+}
+
+// GetStringExpanded 读取字符串配置，并用调用方提供的变量替换其中的 ${name}（如消息模板 welcome = 你好，${user}）
+// 适合请求处理时注入运行时数据；vars 中没有的 ${name} 在配置文件的值中仍按环境变量引用展开，
+// 在命令行参数、环境变量的值以及默认值中则原样保留；变量的值原样写入，不会被再次展开
+func GetStringExpanded(section, key, defaultValue string, vars map[string]string) string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	value, source, exists := lookupRawLocked(section, key)
+	if !exists {
+		return expandVars(defaultValue, vars)
+	}
+	if source == sourceLiteral {
+		return value // @file 引用的文件内容原样返回
+	}
+
+	// 配置文件中的普通值：变量与环境变量引用一次展开，变量优先
+	if source == sourceProvider && !isTemplateValue(value) {
+		return expandRefs(value, func(name string) (string, bool) {
+			if v, ok := vars[name]; ok {
+				return v, true
+			}
+			return lookupEnvRef(name)
+		})
+	}
+	return expandVars(processSourceValue(value, source), vars)
+}
+
+// 只替换 vars 中存在的 ${name}，其余内容原样保留
+func expandVars(value string, vars map[string]string) string {
+	if len(vars) == 0 || !strings.Contains(value, "${") {
+		return value
+	}
+
+	var sb strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(value[start+2:], '}')
+		if end < 0 {
+			break
+		}
+
+		name := value[start+2 : start+2+end]
+		sb.WriteString(value[:start])
+		if v, ok := vars[name]; ok {
+			sb.WriteString(v)
+		} else {
+			sb.WriteString(value[start : start+3+end])
+		}
+		value = value[start+3+end:]
+	}
+	sb.WriteString(value)
+	return sb.String()
+}
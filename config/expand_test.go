@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestEnvRefDefault(t *testing.T) {
+	loadTestConfig(t, "[db]\nhost = ${DB_TEST_HOST:-localhost}\nurl = postgres://${DB_TEST_HOST:-localhost}:${DB_TEST_PORT:-5432}/app\n")
+
+	tests := []struct {
+		name      string
+		set       bool
+		env       string
+		host, url string
+	}{
+		{"已设置", true, "db.internal", "db.internal", "postgres://db.internal:5432/app"},
+		{"未设置", false, "", "localhost", "postgres://localhost:5432/app"},
+		{"为空", true, "", "localhost", "postgres://localhost:5432/app"}, // :- 在变量为空时同样使用默认值
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_TEST_HOST", tt.env) // 测试结束后恢复原值
+			if !tt.set {
+				os.Unsetenv("DB_TEST_HOST")
+			}
+			if got := mustLookup(t, "db", "host"); got != tt.host {
+				t.Errorf("host = %q, want %q", got, tt.host)
+			}
+			if got := mustLookup(t, "db", "url"); got != tt.url {
+				t.Errorf("url = %q, want %q", got, tt.url)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 声明配置文件继承关系的节：[meta] extends = base.ini
+const metaSection = "meta"
+
+// 解析配置文件，并处理 [meta] extends 声明的基础配置文件
+// 先加载基础文件（相对路径基于当前文件所在目录），再用当前文件覆盖；基础文件可以继续继承，
+// 每个文件中的 @file 引用各自基于所在目录解析（见 resolveFileRefs）
+// chain 记录已经在继承链上的文件（绝对路径），出现循环时返回错误
+func parseIniFileChain(filePath string, chain []string) (*parseResult, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+	if containsString(chain, absPath) {
+		return nil, fmt.Errorf("配置文件继承存在循环：%s", strings.Join(append(chain, absPath), " → "))
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := parseConfigData(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveFileRefs(result, filePath); err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+
+	basePath := expandHome(strings.TrimSpace(result.sections[metaSection]["extends"]))
+	if basePath == "" {
+		return result, nil
+	}
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(filepath.Dir(filePath), basePath)
+	}
+
+	base, err := parseIniFileChain(basePath, append(chain, absPath))
+	if err != nil {
+		return nil, fmt.Errorf("加载 %s 继承的配置文件失败: %w", filePath, err)
+	}
+	return mergeParseResults(base, result), nil
+}
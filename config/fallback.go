@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 节的回退链：section → 依次回退查找的节（读写均需持有 configMu）
+var sectionFallbacks = make(map[string][]string)
+
+// 所有节共用的默认节（为空表示不启用，读写均需持有 configMu）
+var defaultSection string
+
+// SetDefaultSection 指定配置文件中存放默认值的节（如 [defaults]），其他节缺少某个键时使用该节中的同名键，
+// 让默认值跟随配置文件一起版本化管理；传入 "" 关闭。启用后单个键的完整查找顺序为：
+//  1. 运行时覆盖层（PushOverrides）
+//  2. 命令行参数（RegisterFlags）
+//  3. 环境变量（BindEnv 绑定的变量，然后是 APP_{SECTION}_{KEY}）
+//  4. 本节在配置来源中的值（配置文件、内嵌默认配置、RegisterProvider 注册的来源）
+//  5. 回退链上各节在配置来源中的值（SetSectionFallbacks）
+//  6. 默认节在配置来源中的值
+//  7. 调用方传入的默认值
+//
+// 默认节只按配置来源查找，APP_DEFAULTS_{KEY} 这类环境变量不会作用于其他节
+func SetDefaultSection(section string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	defaultSection = section
+}
+
+// SetSectionFallbacks 设置节的回退链：主节中找不到的键依次到回退节中查找，全部找不到时才使用默认值
+// 回退可以传递，如 server.worker → server、server → defaults 时，[server.worker] 的查找顺序为 server.worker → server → defaults
+// 命令行参数和环境变量仍只按主节匹配，并优先于任何回退节中的值；fallbacks 为空时删除该节的回退设置
+// 回退链出现环时打印警告，查找时每个节最多访问一次，不会死循环
+func SetSectionFallbacks(section string, fallbacks []string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if len(fallbacks) == 0 {
+		delete(sectionFallbacks, section)
+		return
+	}
+	sectionFallbacks[section] = append([]string(nil), fallbacks...)
+
+	if cycle := findFallbackCycleLocked(section, []string{section}); cycle != nil {
+		fmt.Printf("警告：节回退链存在循环：%s，重复的节将被跳过\n", strings.Join(cycle, " → "))
+	}
+}
+
+// 从 section 出发查找回到起点的回退路径，不存在时返回 nil（调用方需持有 configMu）
+func findFallbackCycleLocked(start string, path []string) []string {
+	current := path[len(path)-1]
+	for _, next := range sectionFallbacks[current] {
+		if next == start {
+			return append(append([]string(nil), path...), next)
+		}
+		if containsString(path, next) {
+			continue // 不经过起点的环，由以该环上的节为起点的检查负责
+		}
+		if cycle := findFallbackCycleLocked(start, append(path, next)); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// 节及其回退链中的全部节，按查找顺序排列（深度优先，每个节只出现一次；调用方需持有 configMu 读锁）
+func sectionChainLocked(section string) []string {
+	return appendFallbacksLocked([]string{section}, section)
+}
+
+// 将 section 的回退节（及其各自的回退节）依次追加到 chain，已出现的节跳过
+func appendFallbacksLocked(chain []string, section string) []string {
+	for _, fallback := range sectionFallbacks[section] {
+		if containsString(chain, fallback) {
+			continue
+		}
+		chain = appendFallbacksLocked(append(chain, fallback), fallback)
+	}
+	return chain
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsEnabledForKeyBooleanWords(t *testing.T) {
+	loadTestConfig(t, "[features]\non = yes\noff = false\n")
+
+	for i := 0; i < 50; i++ {
+		user := fmt.Sprint("user-", i)
+		if !IsEnabledForKey("on", user) {
+			t.Fatalf("on = yes 应对所有对象开启，%s 未开启", user)
+		}
+		if IsEnabledForKey("off", user) {
+			t.Fatalf("off = false 应对所有对象关闭，%s 已开启", user)
+		}
+	}
+}
+
+func TestIsEnabledForKeyIntegerIsPercent(t *testing.T) {
+	loadTestConfig(t, "[features]\none = 1\nnone = 0\nall = 100\n")
+
+	var one int
+	for i := 0; i < 1000; i++ {
+		user := fmt.Sprint("user-", i)
+		if IsEnabledForKey("one", user) {
+			one++
+		}
+		if IsEnabledForKey("none", user) {
+			t.Fatalf("0 应对所有对象关闭，%s 已开启", user)
+		}
+		if !IsEnabledForKey("all", user) {
+			t.Fatalf("100 应对所有对象开启，%s 未开启", user)
+		}
+	}
+	if one == 0 || one > 50 {
+		t.Errorf("new_ui = 1 应为 1%% 灰度，实际开启了 %d/1000", one)
+	}
+}
+
+func TestIsEnabledForKeyPercent(t *testing.T) {
+	loadTestConfig(t, "[features]\nquarter = 25\nsmall = 1%\n")
+
+	var quarter, small int
+	for i := 0; i < 1000; i++ {
+		user := fmt.Sprint("user-", i)
+		if IsEnabledForKey("quarter", user) {
+			quarter++
+		}
+		if IsEnabledForKey("small", user) {
+			small++
+		}
+		if IsEnabledForKey("quarter", user) != IsEnabledForKey("quarter", user) {
+			t.Fatalf("%s 的结果不确定", user)
+		}
+	}
+	if quarter < 150 || quarter > 350 {
+		t.Errorf("25%% 灰度开启了 %d/1000", quarter)
+	}
+	if small == 0 || small > 50 {
+		t.Errorf("1%% 灰度开启了 %d/1000", small)
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 将配置文件中以 @ 开头的值替换为所引用文件的内容（如 private_key = @/etc/keys/login.pem），
+// 使体积较大或敏感的内容不必写进配置文件；在加载时读取一次，文件之后的修改要 Reload 才会生效
+// 相对路径基于配置文件所在目录，支持 ~ 展开；文件内容末尾的一个换行符会被去掉
+// 文件内容原样作为值返回（记录在 result.literal 中），不会展开其中的 $、也不会渲染 {{ }}，适合密码和 PEM 证书
+// 以 @@ 开头的值表示字面量 @（@@user → @user）；[meta] 节不处理
+// 引用的文件不存在或无法读取时返回带配置项名称的错误，整个文件加载失败
+// 只有本地配置文件（Load/Reload/LoadFile、extends 继承的文件、LoadEncrypted）会解析 @ 引用；
+// LoadFromURL、LoadFromCommand 与 SetEmbeddedDefault 的内容没有可信的本地目录，其中以 @ 开头的值按普通值处理
+func resolveFileRefs(result *parseResult, configPath string) error {
+	baseDir := filepath.Dir(configPath)
+	for section, values := range result.sections {
+		if section == metaSection {
+			continue
+		}
+		for key, value := range values {
+			if !strings.HasPrefix(value, "@") {
+				continue
+			}
+			if strings.HasPrefix(value, "@@") {
+				values[key] = value[1:]
+				continue
+			}
+
+			path := expandHome(strings.TrimSpace(value[1:]))
+			if path == "" {
+				return fmt.Errorf("配置项 [%s] %s 的文件引用为空", section, key)
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(baseDir, path)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("配置项 [%s] %s 引用的文件读取失败: %w", section, key, err)
+			}
+			content := strings.TrimSuffix(string(data), "\n")
+			values[key] = strings.TrimSuffix(content, "\r")
+			if result.literal == nil {
+				result.literal = make(map[[2]string]bool)
+			}
+			result.literal[[2]string{section, key}] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileRefVerbatim(t *testing.T) {
+	dir := t.TempDir()
+	pem := "-----BEGIN KEY-----\nab$HOME{{ .env.USER }}\n-----END KEY-----"
+	if err := os.WriteFile(filepath.Join(dir, "key.pem"), []byte(pem+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "password"), []byte("pa$$w0rd$HOME_X9"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.ini")
+	content := "[auth]\nprivate_key = @key.pem\npassword = @password\nhandle = @@bob\nhome = $HOME\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(resetTestConfig)
+	if err := Load(path); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"private_key": pem,
+		"password":    "pa$$w0rd$HOME_X9",
+		"handle":      "@bob",
+		"home":        os.Getenv("HOME"),
+	}
+	for key, want := range cases {
+		if got := mustLookup(t, "auth", key); got != want {
+			t.Errorf("[auth] %s = %q，期望 %q", key, got, want)
+		}
+	}
+	if got := GetStringExpanded("auth", "password", "", map[string]string{"HOME_X9": "x"}); got != "pa$$w0rd$HOME_X9" {
+		t.Errorf("GetStringExpanded = %q", got)
+	}
+	if _, source, _ := LookupWithSource("auth", "password"); source != SourceFile {
+		t.Errorf("来源 = %v，期望 SourceFile", source)
+	}
+
+	// Set 之后的值是普通值，照常展开
+	Set("auth", "password", "$HOME")
+	if got := mustLookup(t, "auth", "password"); got != os.Getenv("HOME") {
+		t.Errorf("Set 后 password = %q", got)
+	}
+}
+
+func TestFileRefMissing(t *testing.T) {
+	path := writeTestFile(t, "config.ini", "[auth]\nprivate_key = @missing.pem\n")
+	_, err := LoadFile(path)
+	if err == nil || !strings.Contains(err.Error(), "[auth] private_key") {
+		t.Errorf("LoadFile 错误 = %v，期望包含配置项名称", err)
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+)
+
+// 已注册的命令行参数集合（读写均需持有 configMu）
+var flagSets []*flag.FlagSet
+
+// 带节名的完整键名：section.key（用于命令行参数名、Flatten 等）
+func dottedKey(section, key string) string {
+	return section + "." + key
+}
+
+// RegisterFlags 为配置文件中已加载的每个键注册一个字符串参数（参数名：section.key，默认值为文件中的值）
+// fs.Parse 之后，显式设置过的参数（通过 fs.Visit 判断）成为 GetConfig 的最高优先级来源：
+// 命令行参数 → 环境变量 → 配置文件 → 默认值
+// 已存在同名参数时跳过，不会覆盖调用方自己注册的参数
+func RegisterFlags(fs *flag.FlagSet) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	sections := make([]string, 0, len(config))
+	for section := range config {
+		if !envOnlySections[section] {
+			sections = append(sections, section) // 仅环境变量的节不注册参数（见 MarkSectionEnvOnly）
+		}
+	}
+	sort.Strings(sections)
+
+	for _, section := range sections {
+		keys := make([]string, 0, len(config[section]))
+		for key := range config[section] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			name := dottedKey(section, key)
+			if fs.Lookup(name) != nil {
+				continue
+			}
+			fs.String(name, config[section][key], fmt.Sprintf("覆盖配置项 [%s] %s", section, key))
+		}
+	}
+	flagSets = append(flagSets, fs)
+}
+
+// 查找显式设置过的命令行参数（调用方需持有 configMu 读锁）
+func lookupFlagLocked(section, key string) (string, bool) {
+	name := dottedKey(section, key)
+	for _, fs := range flagSets {
+		value, found := "", false
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == name {
+				value, found = f.Value.String(), true
+			}
+		})
+		if found {
+			return value, true
+		}
+	}
+	return "", false
+}
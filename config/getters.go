@@ -0,0 +1,547 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 辅助函数：获取单字符配置（如 csv_delimiter = ;），按 UTF-8 解码，多字节字符也视为一个字符
+// 值为空或包含多于一个字符时视为无效，返回默认值（不会截取首字符，避免静默接受错误配置）
+// 需要空格等空白字符时请加引号：delimiter = " "
+func getRuneConfig(section, key string, defaultValue rune) rune {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	r, size := utf8.DecodeRuneInString(value)
+	if r == utf8.RuneError || size != len(value) {
+		warnInvalidValue(section, key, value, fmt.Errorf("必须是单个字符"))
+		return defaultValue
+	}
+	return r
+}
+
+// GetValidated 读取字符串配置（去除首尾空白）并运行校验函数，返回值与校验错误
+// 校验失败时返回的仍是配置中的值，由调用方决定如何处理
+// 键不存在时直接返回默认值且不做校验（默认值由代码提供，视为可信）
+func GetValidated(section, key, defaultValue string, validate func(string) error) (string, error) {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue, nil
+	}
+
+	value = strings.TrimSpace(value)
+	if err := validate(value); err != nil {
+		return value, fmt.Errorf("配置项 [%s] %s 校验失败: %w", section, key, err)
+	}
+	return value, nil
+}
+
+// GetStringNormalized 读取字符串配置并在返回前统一做规范化处理（默认值同样经过处理），
+// 用于消除 "/app" 与 "/app/" 这类写法差异，例如去掉 URL 末尾的斜杠：
+//
+//	base := config.GetStringNormalized("api", "base_url", "", func(s string) string { return strings.TrimRight(s, "/") })
+//
+// 文件路径请使用 getPathConfig，其 filepath.Clean 已会去掉末尾的分隔符
+func GetStringNormalized(section, key, defaultValue string, normalize func(string) string) string {
+	value, exists := lookup(section, key)
+	if !exists {
+		value = defaultValue
+	}
+	return normalize(value)
+}
+
+// GetStringTrim 读取字符串配置并用 strings.Trim 去掉首尾属于 cutset 的字符（默认值同样处理），
+// 用于外部系统写入的、带非引号定界符的值，如 callback = <https://example.com/cb> 配合 cutset "<>"
+// 解析时已有的去空白、去引号规则不变，其他 getter 也不受影响
+func GetStringTrim(section, key, defaultValue, cutset string) string {
+	return GetStringNormalized(section, key, defaultValue, func(s string) string {
+		return strings.Trim(s, cutset)
+	})
+}
+
+// 辅助函数：获取需要格式校验的字符串配置，各类格式校验 getter 的公共基础（基于 GetValidated）
+// 校验失败时打印警告并返回默认值；键不存在时直接返回默认值（默认值不校验）
+func getValidatedConfig(section, key, defaultValue string, validate func(string) error) string {
+	value, err := GetValidated(section, key, defaultValue, validate)
+	if err != nil {
+		warnInvalidValue(section, key, value, errors.Unwrap(err))
+		return defaultValue
+	}
+	return value
+}
+
+// 辅助函数：获取带长度与字符限制的字符串配置（如用户名前缀，之后会拼入 URL 或文件路径）
+// 值（去除首尾空白后）按字符数超过 maxLen（maxLen <= 0 表示不限制），或包含 allowed 之外的字符时打印警告并返回默认值
+// allowed 描述单个允许的字符（如 [a-z0-9_-]），为 nil 时不限制字符；默认值不做校验
+func getConstrainedStringConfig(section, key, defaultValue string, maxLen int, allowed *regexp.Regexp) string {
+	return getValidatedConfig(section, key, defaultValue, func(value string) error {
+		if maxLen > 0 && utf8.RuneCountInString(value) > maxLen {
+			return fmt.Errorf("长度超过 %d 个字符", maxLen)
+		}
+		if allowed == nil {
+			return nil
+		}
+		for _, r := range value {
+			if !allowed.MatchString(string(r)) {
+				return fmt.Errorf("包含不允许的字符 %q", r)
+			}
+		}
+		return nil
+	})
+}
+
+// 辅助函数：获取邮箱地址配置（如告警收件人），按 net/mail 规则校验
+func getEmailConfig(section, key, defaultValue string) string {
+	return getValidatedConfig(section, key, defaultValue, func(value string) error {
+		_, err := mail.ParseAddress(value)
+		return err
+	})
+}
+
+// 计数后缀倍数（十进制，区别于字节大小的 KB/MB）
+var countMultipliers = map[byte]int64{
+	'k': 1000,
+	'm': 1000 * 1000,
+	'g': 1000 * 1000 * 1000,
+}
+
+// 辅助函数：获取带 k/m/g 后缀的计数配置（如 max_items = 10k → 10000，1.5m → 1500000）
+// 后缀忽略大小写，按十进制倍数计算；格式错误、结果不是整数或溢出时返回默认值
+func getCountConfig(section, key string, defaultValue int64) int64 {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	count, err := parseCount(value)
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	return count
+}
+
+// 辅助函数：解析带 k/m/g 后缀的计数
+func parseCount(value string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	multiplier := int64(1)
+	if s != "" {
+		if m, ok := countMultipliers[s[len(s)-1]]; ok {
+			multiplier = m
+			s = strings.TrimSpace(s[:len(s)-1])
+		}
+	}
+
+	if !strings.Contains(s, ".") {
+		n, err := strconv.ParseInt(stripThousandsSeparators(s), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if n > math.MaxInt64/multiplier || n < math.MinInt64/multiplier {
+			return 0, fmt.Errorf("数值溢出")
+		}
+		return n * multiplier, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	total := f * float64(multiplier)
+	if total != math.Trunc(total) || math.Abs(total) >= math.MaxInt64 {
+		return 0, fmt.Errorf("结果不是有效的整数")
+	}
+	return int64(total), nil
+}
+
+// 十六进制颜色：3 位或 6 位，# 可省略
+var hexColorPattern = regexp.MustCompile(`^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// 辅助函数：获取十六进制颜色配置（如登录页主题色 primary_color = #1E90FF）
+// 接受 3 位或 6 位格式（# 可省略），统一返回小写的 #rrggbb；无效时打印警告并返回默认值
+func getHexColorConfig(section, key, defaultValue string) string {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	value = strings.TrimSpace(value)
+	match := hexColorPattern.FindStringSubmatch(value)
+	if match == nil {
+		warnInvalidValue(section, key, value, fmt.Errorf("不是合法的十六进制颜色"))
+		return defaultValue
+	}
+
+	hex := strings.ToLower(match[1])
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	return "#" + hex
+}
+
+// 辅助函数：与 getIntConfig 相同，但值无法解析时无论是否开启严格类型模式都打印警告，
+// 供文档承诺"值无效时打印警告"的结构化配置读取函数使用
+func getCheckedIntConfig(section, key string, defaultValue int) int {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(stripThousandsSeparators(strings.TrimSpace(value)))
+	if err != nil {
+		warnInvalidValue(section, key, value, fmt.Errorf("应为整数"))
+		return defaultValue
+	}
+	return n
+}
+
+// 辅助函数：与 getBoolConfig 相同，但值无法识别时无论是否开启严格类型模式都打印警告
+func getCheckedBoolConfig(section, key string, defaultValue bool) bool {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	if b, ok := parseBoolValue(value); ok {
+		return b
+	}
+	warnInvalidValue(section, key, value, fmt.Errorf("无法识别的布尔值"))
+	return defaultValue
+}
+
+// 辅助函数：获取时长配置（如 timeout = 1m30s），按 time.ParseDuration 解析
+// 值无效或为负数时打印警告并返回默认值；仅以整数秒表示的旧格式请使用 getSecondsConfig
+func getDurationConfig(section, key string, defaultValue time.Duration) time.Duration {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	if d < 0 {
+		warnInvalidValue(section, key, value, fmt.Errorf("时长不能为负数"))
+		return defaultValue
+	}
+	return d
+}
+
+// 辅助函数：获取 [0, 1] 范围内的比例配置（如采样率 sample_rate = 0.25）
+// 超出范围的值不会被截断到边界，而是与无法解析的值一样打印警告并返回默认值，避免 1.5 这类错误配置被静默接受
+func getRatioConfig(section, key string, defaultValue float64) float64 {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	ratio, err := parseFloatValue(value)
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	if ratio < 0 || ratio > 1 {
+		warnInvalidValue(section, key, value, fmt.Errorf("必须在 0 到 1 之间"))
+		return defaultValue
+	}
+	return ratio
+}
+
+// 辅助函数：获取以整数秒表示的时长配置（兼容 timeout = 30 这类旧格式），返回 time.Duration
+// 值必须是非负整数，否则打印警告并返回默认值；需要 30s/1m 等格式请使用完整的时长解析
+func getSecondsConfig(section, key string, defaultValue time.Duration) time.Duration {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || seconds < 0 || seconds > int64(math.MaxInt64/time.Second) {
+		warnInvalidValue(section, key, value, fmt.Errorf("必须是非负整数秒"))
+		return defaultValue
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// 辅助函数：获取日志级别配置（如 log_level = debug），返回 slog.Level
+// 支持 debug、info、warn（或 warning）、error（忽略大小写）及 slog 的偏移写法（如 warn+2），也接受整数级别（如 -4、8）
+// 无法识别时打印警告并返回默认值
+func getLogLevelConfig(section, key string, defaultValue slog.Level) slog.Level {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	text := strings.TrimSpace(value)
+	if n, err := strconv.Atoi(text); err == nil {
+		return slog.Level(n)
+	}
+	if strings.EqualFold(text, "warning") {
+		return slog.LevelWarn
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(text)); err != nil {
+		warnInvalidValue(section, key, value, fmt.Errorf("日志级别应为 debug、info、warn、error 或整数"))
+		return defaultValue
+	}
+	return level
+}
+
+// 星期名称（小写）→ time.Weekday，同时支持三字母缩写和完整英文名称
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// 辅助函数：获取逗号分隔的星期列表配置（如维护窗口 days = mon,wed,fri），返回 []time.Weekday
+// 每个元素去除空白后按缩写（mon/tue/.../sun）或完整英文名称（monday 等）解析，忽略大小写；
+// 任一元素无法识别时打印警告并返回默认列表
+func getWeekdaysConfig(section, key string, defaultValue []time.Weekday) []time.Weekday {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	items := strings.Split(value, ",")
+	days := make([]time.Weekday, 0, len(items))
+	for _, item := range items {
+		day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(item))]
+		if !ok {
+			warnInvalidValue(section, key, value, fmt.Errorf("无法识别的星期 %q", strings.TrimSpace(item)))
+			return defaultValue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// 辅助函数：获取时区配置（如 tz = America/New_York），按 time.LoadLocation 加载
+// UTC 和 Local（忽略大小写）分别返回 time.UTC 与 time.Local；时区名无效（或系统缺少时区数据）时打印警告并返回默认值
+func getTimeZoneConfig(section, key string, defaultValue *time.Location) *time.Location {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	name := strings.TrimSpace(value)
+	switch {
+	case strings.EqualFold(name, "UTC"):
+		return time.UTC
+	case strings.EqualFold(name, "Local"):
+		return time.Local
+	case name == "":
+		warnInvalidValue(section, key, value, fmt.Errorf("时区不能为空"))
+		return defaultValue
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	return loc
+}
+
+// 辅助函数：获取 UUID 配置（如租户 ID、实例 ID），接受带连字符（8-4-4-4-12）和不带连字符（32 位十六进制）两种写法，
+// 统一返回小写的带连字符标准格式；格式无效时打印警告并返回默认值（默认值原样返回，不做规范化）
+func getUUIDConfig(section, key, defaultValue string) string {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	uuid, err := normalizeUUID(strings.TrimSpace(value))
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	return uuid
+}
+
+// 将 UUID 规范化为小写的 8-4-4-4-12 格式
+func normalizeUUID(s string) (string, error) {
+	hex := s
+	if len(s) == 36 {
+		for _, i := range []int{8, 13, 18, 23} {
+			if s[i] != '-' {
+				return "", fmt.Errorf("UUID 格式无效")
+			}
+		}
+		hex = strings.ReplaceAll(s, "-", "")
+	}
+	if len(hex) != 32 {
+		return "", fmt.Errorf("UUID 应为 32 位十六进制数字（可带连字符）")
+	}
+	for i := 0; i < len(hex); i++ {
+		c := hex[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return "", fmt.Errorf("UUID 包含非十六进制字符 %q", c)
+		}
+	}
+
+	hex = strings.ToLower(hex)
+	return hex[0:8] + "-" + hex[8:12] + "-" + hex[12:16] + "-" + hex[16:20] + "-" + hex[20:32], nil
+}
+
+// 辅助函数：获取以 Unix 秒表示的时间戳配置（如 cutoff = 1700000000），返回 time.Time（本地时区）
+// 值不是整数时打印警告并返回默认值；毫秒时间戳请使用 getUnixMilliTimeConfig
+func getUnixTimeConfig(section, key string, defaultValue time.Time) time.Time {
+	return getEpochConfig(section, key, defaultValue, time.Unix)
+}
+
+// 辅助函数：获取以 Unix 毫秒表示的时间戳配置（如 cutoff = 1700000000123），其余规则同 getUnixTimeConfig
+func getUnixMilliTimeConfig(section, key string, defaultValue time.Time) time.Time {
+	return getEpochConfig(section, key, defaultValue, func(ms, _ int64) time.Time {
+		return time.UnixMilli(ms)
+	})
+}
+
+// 按给定精度将整数时间戳转换为 time.Time
+func getEpochConfig(section, key string, defaultValue time.Time, convert func(int64, int64) time.Time) time.Time {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		warnInvalidValue(section, key, value, fmt.Errorf("时间戳必须是整数"))
+		return defaultValue
+	}
+	return convert(n, 0)
+}
+
+// 辅助函数：获取带比较运算符的阈值配置（如告警规则 cpu = >80、latency = <=200），返回运算符与数值
+// 支持 >、>=、<、<=、==，运算符与数字之间可以有空白；没有运算符时视为 >=
+// 键不存在（ErrMissingKey）、运算符无效或数字无法解析时返回错误
+func getThresholdConfig(section, key string) (string, float64, error) {
+	value, exists := lookup(section, key)
+	if !exists {
+		return "", 0, fmt.Errorf("配置项 [%s] %s: %w", section, key, ErrMissingKey)
+	}
+
+	text := strings.TrimSpace(value)
+	op := ">="
+	for _, candidate := range []string{">=", "<=", "==", ">", "<"} {
+		if strings.HasPrefix(text, candidate) {
+			op, text = candidate, text[len(candidate):]
+			break
+		}
+	}
+
+	threshold, err := parseFloatValue(text)
+	if err != nil {
+		return "", 0, fmt.Errorf("配置项 [%s] %s 的阈值 %q 无效: %w", section, key, value, err)
+	}
+	return op, threshold, nil
+}
+
+// 辅助函数：获取语义化版本配置（如 min_client = 1.4.0），返回主、次、修订版本号
+// 允许前导 v（v1.4.0），预发布和构建元数据后缀（1.4.0-rc.1、1.4.0+build5）会被忽略
+// 键不存在时解析 defaultValue；版本号不是三段非负整数时返回错误
+func getSemVerConfig(section, key, defaultValue string) (major, minor, patch int, err error) {
+	value, exists := lookup(section, key)
+	if !exists {
+		value = defaultValue
+	}
+
+	major, minor, patch, err = parseSemVer(value)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("配置项 [%s] %s 的版本号 %q 无效: %w", section, key, value, err)
+	}
+	return major, minor, patch, nil
+}
+
+// 解析 MAJOR.MINOR.PATCH 形式的版本号
+func parseSemVer(s string) (major, minor, patch int, err error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, errors.New("版本号必须是 MAJOR.MINOR.PATCH 三段")
+	}
+	nums := make([]int, 3)
+	for i, part := range parts {
+		if part == "" || strings.TrimLeft(part, "0123456789") != "" {
+			return 0, 0, 0, fmt.Errorf("版本号分段 %q 不是非负整数", part)
+		}
+		if nums[i], err = strconv.Atoi(part); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	return nums[0], nums[1], nums[2], nil
+}
+
+// 限流窗口的单位名称
+var rateLimitUnits = map[string]time.Duration{
+	"second": time.Second,
+	"sec":    time.Second,
+	"s":      time.Second,
+	"minute": time.Minute,
+	"min":    time.Minute,
+	"m":      time.Minute,
+	"hour":   time.Hour,
+	"h":      time.Hour,
+	"day":    24 * time.Hour,
+	"d":      24 * time.Hour,
+}
+
+// 辅助函数：获取 N/窗口 形式的限流配置（如 login = 5/minute、login = 5/1m），返回次数与窗口时长
+// 窗口可以是单位名称（second、minute、hour、day 及缩写，忽略大小写）或时长（如 30s、1m）
+// 键不存在（ErrMissingKey）、格式无效、次数不是正整数或窗口不是正时长时返回错误
+func getRateLimitConfig(section, key string) (count int, per time.Duration, err error) {
+	value, exists := lookup(section, key)
+	if !exists {
+		return 0, 0, fmt.Errorf("配置项 [%s] %s: %w", section, key, ErrMissingKey)
+	}
+
+	count, per, err = parseRateLimit(value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("配置项 [%s] %s 的限流值 %q 无效: %w", section, key, value, err)
+	}
+	return count, per, nil
+}
+
+// 解析 N/unit 或 N/duration
+func parseRateLimit(s string) (int, time.Duration, error) {
+	countText, window, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, 0, errors.New("格式应为 次数/窗口（如 5/minute）")
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(countText))
+	if err != nil || count <= 0 {
+		return 0, 0, errors.New("次数必须是正整数")
+	}
+
+	window = strings.ToLower(strings.TrimSpace(window))
+	if per, ok := rateLimitUnits[window]; ok {
+		return count, per, nil
+	}
+	per, err := time.ParseDuration(window)
+	if err != nil || per <= 0 {
+		return 0, 0, fmt.Errorf("无法识别的窗口 %q", window)
+	}
+	return count, per, nil
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// 在临时目录中写入文件，返回文件路径
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// 将内容写入临时配置文件并 Load，测试结束后恢复为空配置
+func loadTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := writeTestFile(t, "config.ini", content)
+	t.Cleanup(resetTestConfig)
+	if err := Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return path
+}
+
+// 清空已加载的配置与未保存的修改
+func resetTestConfig() {
+	commitWithLock(func() error {
+		commitLocked("", &parseResult{sections: make(map[string]map[string]string), order: make(map[string][]string)})
+		dirtyKeys = make(map[[2]string]bool)
+		return nil
+	})
+}
+
+// 读取配置值，键不存在时测试失败
+func mustLookup(t *testing.T, section, key string) string {
+	t.Helper()
+	value, exists := lookup(section, key)
+	if !exists {
+		t.Fatalf("配置项 [%s] %s 不存在", section, key)
+	}
+	return value
+}
+
+// 读取文件内容
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// 记录日志消息的 Logger
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Info(msg string, args ...interface{}) { l.record("INFO " + msg) }
+func (l *recordingLogger) Warn(msg string, args ...interface{}) { l.record("WARN " + msg) }
+
+func (l *recordingLogger) record(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, msg)
+}
+
+// 是否记录过指定消息（形如 "INFO config loaded"）
+func (l *recordingLogger) has(msg string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, m := range l.messages {
+		if m == msg {
+			return true
+		}
+	}
+	return false
+}
+
+// 在测试期间使用 recordingLogger，结束后恢复默认日志
+func useRecordingLogger(t *testing.T) *recordingLogger {
+	t.Helper()
+	l := &recordingLogger{}
+	SetLogger(l)
+	t.Cleanup(func() { SetLogger(nil) })
+	return l
+}
+
+// 运行 fn 并返回其间写到标准输出的内容（warnInvalidValue 等警告直接打印到标准输出）
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
@@ -0,0 +1,72 @@
+package config
+
+import "sort"
+
+// Config 一份独立的配置实例（节 → 键 → 值），修改不会影响全局配置或其他实例
+// 包级函数仍基于全局配置工作；Config 用于测试或子系统持有自己的副本（如试算一组覆盖值）
+type Config struct {
+	sections map[string]map[string]string
+	order    map[string][]string
+}
+
+// Snapshot 返回当前已加载配置（配置文件与内嵌默认配置合并后的内容）的独立副本，不含环境变量覆盖
+func Snapshot() *Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return (&Config{sections: config, order: keyOrder}).Clone()
+}
+
+// LoadFile 将配置文件（按内容识别 INI 或 JSON，含 [meta] extends 继承）解析为独立的配置实例，不影响全局配置
+// 不合并内嵌默认配置，也不运行已注册的校验器
+func LoadFile(path string) (*Config, error) {
+	result, err := parseIniFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{sections: result.sections, order: result.order}, nil
+}
+
+// Clone 返回深拷贝，副本与原实例互不影响
+func (c *Config) Clone() *Config {
+	clone := &Config{
+		sections: make(map[string]map[string]string, len(c.sections)),
+		order:    make(map[string][]string, len(c.order)),
+	}
+	for section, values := range c.sections {
+		clone.sections[section] = make(map[string]string, len(values))
+		for key, value := range values {
+			clone.sections[section][key] = value
+		}
+	}
+	for section, keys := range c.order {
+		clone.order[section] = append([]string(nil), keys...)
+	}
+	return clone
+}
+
+// Get 读取实例中的原始值，第二个返回值表示键是否存在
+func (c *Config) Get(section, key string) (string, bool) {
+	value, exists := c.sections[section][key]
+	return value, exists
+}
+
+// Set 设置实例中的值（节不存在时自动创建），只影响当前实例
+func (c *Config) Set(section, key, value string) {
+	if c.sections[section] == nil {
+		c.sections[section] = make(map[string]string)
+	}
+	if _, exists := c.sections[section][key]; !exists {
+		c.order[section] = append(c.order[section], key)
+	}
+	c.sections[section][key] = value
+}
+
+// Sections 返回实例中的全部节名（按字母排序）
+func (c *Config) Sections() []string {
+	names := make([]string, 0, len(c.sections))
+	for section := range c.sections {
+		names = append(names, section)
+	}
+	sort.Strings(names)
+	return names
+}
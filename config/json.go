@@ -0,0 +1,57 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// 解析JSON格式配置：顶层对象的每个键为节名，值为 键 → 值 的对象
+// 例如 {"app": {"name": "did-new", "port": 50107, "debug": false}}
+// 数字、布尔值按原样转为字符串；顶层非对象的条目和节内的嵌套对象/数组计入跳过数
+func parseJSONConfig(data []byte) (*parseResult, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw map[string]json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("JSON 配置解析失败: %w", err)
+	}
+
+	result := &parseResult{sections: make(map[string]map[string]string), order: make(map[string][]string)}
+	for section, body := range raw {
+		var values map[string]interface{}
+		sectionDecoder := json.NewDecoder(bytes.NewReader(body))
+		sectionDecoder.UseNumber()
+		if err := sectionDecoder.Decode(&values); err != nil || values == nil {
+			result.stats.skipped++
+			continue
+		}
+
+		sectionMap := make(map[string]string, len(values))
+		for key, value := range values {
+			switch v := value.(type) {
+			case string:
+				sectionMap[key] = v
+			case json.Number, bool:
+				sectionMap[key] = fmt.Sprint(v)
+			case nil:
+				sectionMap[key] = ""
+			default:
+				result.stats.skipped++
+			}
+		}
+		keys := make([]string, 0, len(sectionMap))
+		for key := range sectionMap {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		result.sections[section] = sectionMap
+		result.order[section] = keys
+		result.stats.keys += len(sectionMap)
+	}
+	result.stats.sections = len(result.sections)
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import "testing"
+
+func TestParseConfigDataSniffsFormat(t *testing.T) {
+	tests := []struct {
+		name, content string
+	}{
+		{"JSON", `{"app": {"name": "sniffed"}}`},
+		{"带 BOM 和前导空白的 JSON", "\ufeff\n  {\"app\": {\"name\": \"sniffed\"}}"},
+		{"INI", "[app]\nname = sniffed\n"},
+		{"以注释开头的 INI", "; generated\n\n[app]\nname = sniffed\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseConfigData([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("parseConfigData: %v", err)
+			}
+			if got := result.sections["app"]["name"]; got != "sniffed" {
+				t.Errorf("[app] name = %q, want sniffed", got)
+			}
+		})
+	}
+}
+
+func TestLoadExtensionlessFiles(t *testing.T) {
+	for name, content := range map[string]string{
+		"json": `{"app": {"port": "8081"}}`,
+		"ini":  "[app]\nport = 8081\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := writeTestFile(t, "config", content)
+			t.Cleanup(resetTestConfig)
+			if err := Load(path); err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got := mustLookup(t, "app", "port"); got != "8081" {
+				t.Errorf("[app] port = %q, want 8081", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Logger 配置加载事件的日志接口，*slog.Logger 可直接使用：config.SetLogger(slog.Default())
+// args 为交替出现的字段名与字段值（与 slog 相同）
+type Logger interface {
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+}
+
+var (
+	loggerMu sync.RWMutex
+	logger   Logger = printfLogger{}
+)
+
+// SetLogger 设置配置加载事件（配置文件加载完成、解析失败等）的日志输出，传入 nil 恢复默认的标准输出
+// 注意：init 中的自动加载早于调用方的代码执行，其事件总是输出到默认日志；
+// 需要让启动加载也走自定义日志时，请使用 config_noautoload 构建标签并在 SetLogger 之后调用 Load
+func SetLogger(l Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	if l == nil {
+		l = printfLogger{}
+	}
+	logger = l
+}
+
+// 获取当前日志输出
+func currentLogger() Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return logger
+}
+
+// 记录配置加载事件：成功时为 Info 级别的 "config loaded"，失败时为 Warn 级别的 "config load failed"
+// 字段：path、sections、keys、skipped，以及失败时的 error
+func logLoadEvent(path string, result *parseResult, err error) {
+	if err != nil {
+		currentLogger().Warn("config load failed", "path", path, "error", err)
+		return
+	}
+	currentLogger().Info("config loaded",
+		"path", path,
+		"sections", result.stats.sections,
+		"keys", result.stats.keys,
+		"skipped", result.stats.skipped,
+	)
+}
+
+// 默认日志：以 key=value 形式输出到标准输出，Warn 级别带 "警告：" 前缀
+type printfLogger struct{}
+
+func (printfLogger) Info(msg string, args ...interface{}) {
+	fmt.Println(formatLogLine(msg, args))
+}
+
+func (printfLogger) Warn(msg string, args ...interface{}) {
+	fmt.Println("警告：" + formatLogLine(msg, args))
+}
+
+// 拼接 msg key=value key=value ...（字段名缺少对应值时输出为 !BADKEY=值）
+func formatLogLine(msg string, args []interface{}) string {
+	var sb strings.Builder
+	sb.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			fmt.Fprintf(&sb, " !BADKEY=%v", args[i])
+			break
+		}
+		switch v := args[i+1].(type) {
+		case string:
+			fmt.Fprintf(&sb, " %v=%q", args[i], v)
+		case error:
+			fmt.Fprintf(&sb, " %v=%q", args[i], v.Error())
+		default:
+			fmt.Fprintf(&sb, " %v=%v", args[i], v)
+		}
+	}
+	return sb.String()
+}
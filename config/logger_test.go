@@ -0,0 +1,68 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFromCommandLogsLoadEvent(t *testing.T) {
+	t.Cleanup(resetTestConfig)
+	log := useRecordingLogger(t)
+
+	if err := LoadFromCommand("sh", "-c", `printf '[app]\nname = cmd\n'`); err != nil {
+		t.Fatalf("LoadFromCommand: %v", err)
+	}
+	if !log.has("INFO config loaded") {
+		t.Error("LoadFromCommand 成功后应记录 config loaded")
+	}
+}
+
+func TestLoadEncryptedLogsLoadEvent(t *testing.T) {
+	t.Cleanup(resetTestConfig)
+	log := useRecordingLogger(t)
+
+	key := bytes.Repeat([]byte("k"), 32)
+	path := filepath.Join(t.TempDir(), "config.ini.enc")
+	if err := WriteEncrypted(path, []byte("[app]\nname = enc\n"), key); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadEncrypted(path, key); err != nil {
+		t.Fatalf("LoadEncrypted: %v", err)
+	}
+	if !log.has("INFO config loaded") {
+		t.Error("LoadEncrypted 成功后应记录 config loaded")
+	}
+}
+
+func TestEnvOnlyWarningNotRepeatedOnSet(t *testing.T) {
+	log := useRecordingLogger(t)
+	t.Cleanup(func() {
+		configMu.Lock()
+		delete(envOnlySections, "vault")
+		delete(envOnlyWarned, "vault")
+		configMu.Unlock()
+	})
+
+	loadTestConfig(t, "[vault]\ntoken = leaked\n")
+	MarkSectionEnvOnly("vault")
+	if !log.has("WARN 节只能通过环境变量设置，配置文件中的键已被忽略") {
+		t.Fatal("配置文件中的仅环境变量节应记录警告")
+	}
+
+	log.messages = nil
+	Set("app", "name", "changed")
+	if log.has("WARN 节只能通过环境变量设置，配置文件中的键已被忽略") {
+		t.Error("Set 重新提交同一份配置时不应重复警告")
+	}
+}
+
+func TestSectionConditionWarningUsesLogger(t *testing.T) {
+	log := useRecordingLogger(t)
+	if _, err := parseIni(bytes.NewReader([]byte("[app @if NOT_A_CONDITION]\nname = x\n"))); err != nil {
+		t.Fatalf("parseIni: %v", err)
+	}
+	if !log.has("WARN 节条件无效，忽略该节的内容") {
+		t.Errorf("无效的节条件应通过 Logger 警告，实际: %v", log.messages)
+	}
+}
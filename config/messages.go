@@ -0,0 +1,47 @@
+package config
+
+import "strings"
+
+// 界面文案所在的节名前缀（[messages.en]、[messages.fr] ...）与兜底语言
+const (
+	messagesSectionPrefix = "messages."
+	fallbackLanguage      = "en"
+)
+
+// GetMessage 读取指定语言的界面文案（如登录页提示语），便于运营人员不重新构建就能修改文案
+// 查找顺序：[messages.<lang>] → 带地区的语言依次去掉地区部分（zh-CN → zh）→ [messages.en] → defaultValue
+// 语言代码忽略大小写，下划线视同连字符（zh_CN 等同 zh-cn）；各节按 GetConfig 的优先级解析，
+// 单条文案需要用环境变量覆盖时可通过 BindEnv 绑定（约定的 APP_ 变量名中会包含点号，多数 shell 无法设置）
+//
+//	[messages.en]
+//	login_failed = Invalid username or password
+//
+//	[messages.fr]
+//	login_failed = Identifiant ou mot de passe incorrect
+func GetMessage(lang, key, defaultValue string) string {
+	for _, candidate := range messageLanguages(lang) {
+		if value, exists := lookup(messagesSectionPrefix+candidate, key); exists {
+			return value
+		}
+	}
+	return defaultValue
+}
+
+// 按查找顺序列出候选语言：zh-Hant-TW → zh-hant-tw、zh-hant、zh、en
+func messageLanguages(lang string) []string {
+	lang = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+
+	var candidates []string
+	for lang != "" {
+		candidates = append(candidates, lang)
+		i := strings.LastIndexByte(lang, '-')
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	if !containsString(candidates, fallbackLanguage) {
+		candidates = append(candidates, fallbackLanguage)
+	}
+	return candidates
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 辅助函数：获取 IP 地址类型配置（如 bind_ip = 10.0.0.1），缺失或无效时返回默认值
+func getIPConfig(section, key string, defaultValue net.IP) net.IP {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil {
+		warnInvalidValue(section, key, value, fmt.Errorf("不是合法的 IP 地址"))
+		return defaultValue
+	}
+	return ip
+}
+
+// 辅助函数：获取 CIDR 网段类型配置（如 allow_cidr = 10.0.0.0/8），缺失或无效时返回默认值
+func getCIDRConfig(section, key string, defaultValue *net.IPNet) *net.IPNet {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	_, ipNet, err := net.ParseCIDR(strings.TrimSpace(value))
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	return ipNet
+}
+
+// 辅助函数：获取 host:port 类型配置（如 addr = example.com:8080），分别返回主机和端口
+// 只写端口时（如 :8080）主机使用默认值；缺失或格式错误时返回默认主机和端口
+func getHostPortConfig(section, key string, defaultHost string, defaultPort int) (string, int) {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultHost, defaultPort
+	}
+
+	host, portStr, err := net.SplitHostPort(strings.TrimSpace(value))
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultHost, defaultPort
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 0 || port > 65535 {
+		warnInvalidValue(section, key, value, fmt.Errorf("端口 %q 无效", portStr))
+		return defaultHost, defaultPort
+	}
+
+	if host == "" {
+		host = defaultHost
+	}
+	return host, port
+}
+
+// 辅助函数：获取端口列表配置（如 ports = 8080,8443,9000），每个元素去除空白后必须是 1–65535 的整数
+// 任一元素无效（或列表为空）时打印警告并返回默认列表
+func getPortSliceConfig(section, key string, defaultValue []int) []int {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	var ports []int
+	for _, item := range strings.Split(value, ",") {
+		port, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil || port < 1 || port > 65535 {
+			warnInvalidValue(section, key, value, fmt.Errorf("端口 %q 无效（应为 1-65535）", strings.TrimSpace(item)))
+			return defaultValue
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+// DNS 解析结果的缓存时间与单次解析超时
+const (
+	resolveCacheTTL = 30 * time.Second
+	resolveTimeout  = 5 * time.Second
+)
+
+// 主机名解析缓存：主机名 → 解析结果
+var (
+	resolveMu    sync.Mutex
+	resolveCache = make(map[string]resolvedHost)
+)
+
+type resolvedHost struct {
+	ips     []net.IP
+	expires time.Time
+}
+
+// GetResolvedHosts 读取主机名配置（如 host = db.internal，也可以写成 host:port，端口被忽略）并立即做 DNS 解析，
+// 用于启动前的预检，及早发现无法解析的主机；值本身是 IP 地址时直接返回
+// 键不存在、值为空或解析失败时返回错误；成功的解析结果缓存 30 秒，避免重复查询
+func GetResolvedHosts(section, key string) ([]net.IP, error) {
+	value, exists := lookup(section, key)
+	if !exists {
+		return nil, fmt.Errorf("配置项 [%s] %s 不存在", section, key)
+	}
+
+	host := strings.TrimSpace(value)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "" {
+		return nil, fmt.Errorf("配置项 [%s] %s 的主机名为空", section, key)
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	resolveMu.Lock()
+	cached, ok := resolveCache[host]
+	resolveMu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return append([]net.IP(nil), cached.ips...), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, fmt.Errorf("配置项 [%s] %s 的主机 %s 解析失败: %w", section, key, host, err)
+	}
+
+	resolveMu.Lock()
+	resolveCache[host] = resolvedHost{ips: ips, expires: time.Now().Add(resolveCacheTTL)}
+	resolveMu.Unlock()
+	return append([]net.IP(nil), ips...), nil
+}
@@ -0,0 +1,12 @@
+//go:build config_noautoload
+
+package config
+
+// 使用 -tags config_noautoload 构建时，导入本包不会在 init 中访问磁盘查找和解析配置文件，
+// 由调用方在合适的时机显式调用 Load（或 LoadFromURL）加载配置，适用于测试和作为库嵌入的场景：
+//
+//	go build -tags config_noautoload ./...
+//	go test -tags config_noautoload ./...
+//
+// 此时 APP_NAME 等缓存变量在 init 中先由环境变量和默认值决定，之后每次成功的 Load 都会按新配置重新赋值
+const autoLoad = false
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMissingKey 必填配置项（见 Required）不存在时返回的错误，可用 errors.Is 判断
+var ErrMissingKey = errors.New("必填配置项不存在")
+
+// Option GetString 的读取选项
+type Option func(*getOptions)
+
+type getOptions struct {
+	defaultValue string
+	required     bool
+	validators   []func(string) error
+}
+
+// WithDefault 设置键不存在时返回的默认值（未设置时为空字符串）
+func WithDefault(value string) Option {
+	return func(o *getOptions) {
+		o.defaultValue = value
+	}
+}
+
+// Required 要求配置项必须存在（命令行参数、环境变量或配置来源之一），不存在时返回 ErrMissingKey，忽略 WithDefault
+func Required() Option {
+	return func(o *getOptions) {
+		o.required = true
+	}
+}
+
+// WithValidator 添加校验函数，对读取到的值执行（多个校验函数按添加顺序执行，遇到第一个错误即返回）
+// 与 GetValidated 一致，键不存在时使用的默认值不做校验
+func WithValidator(fn func(string) error) Option {
+	return func(o *getOptions) {
+		o.validators = append(o.validators, fn)
+	}
+}
+
+// GetString 以函数式选项读取字符串配置，优先级同 GetConfig，组合默认值、必填和校验：
+//
+//	addr, err := config.GetString("server", "addr", config.Required(), config.WithValidator(checkAddr))
+//	name, _ := config.GetString("app", "name", config.WithDefault("demo"))
+//
+// 校验失败时返回配置中的值和错误，由调用方决定如何处理；原有的带位置默认值的函数保持不变
+func GetString(section, key string, opts ...Option) (string, error) {
+	var o getOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	value, exists := lookup(section, key)
+	if !exists {
+		if o.required {
+			return "", fmt.Errorf("配置项 [%s] %s: %w", section, key, ErrMissingKey)
+		}
+		return o.defaultValue, nil
+	}
+
+	for _, validate := range o.validators {
+		if err := validate(value); err != nil {
+			return value, fmt.Errorf("配置项 [%s] %s 校验失败: %w", section, key, err)
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,45 @@
+package config
+
+// 运行时覆盖层栈：节 → 键 → 值，后压入的层优先（读写均需持有 configMu）
+var overrideStack []map[string]map[string]string
+
+// PushOverrides 压入一层运行时覆盖值，适合测试或功能实验中临时修改配置，之后用 PopOverrides 干净地还原
+// 覆盖层位于查找链最顶端，优先于命令行参数、环境变量和配置文件；多层时从栈顶开始查找
+// 传入的 map 会被复制，之后修改它不影响已压入的层
+//
+//	config.PushOverrides(map[string]map[string]string{"app": {"debug": "true"}})
+//	defer config.PopOverrides()
+func PushOverrides(overrides map[string]map[string]string) {
+	layer := make(map[string]map[string]string, len(overrides))
+	for section, values := range overrides {
+		layer[section] = make(map[string]string, len(values))
+		for key, value := range values {
+			layer[section][key] = value
+		}
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	overrideStack = append(overrideStack, layer)
+}
+
+// PopOverrides 弹出最近压入的覆盖层；栈为空时不做任何事
+func PopOverrides() {
+	configMu.Lock()
+	defer configMu.Unlock()
+	if len(overrideStack) == 0 {
+		return
+	}
+	overrideStack[len(overrideStack)-1] = nil
+	overrideStack = overrideStack[:len(overrideStack)-1]
+}
+
+// 从栈顶开始查找覆盖值（调用方需持有 configMu 读锁）
+func lookupOverridesLocked(section, key string) (string, bool) {
+	for i := len(overrideStack) - 1; i >= 0; i-- {
+		if value, exists := overrideStack[i][section][key]; exists {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy 登录密码强度规则
+type PasswordPolicy struct {
+	MinLength      int  // 最小长度（按字符数）
+	RequireDigit   bool // 必须包含数字
+	RequireUpper   bool // 必须包含大写字母
+	RequireSpecial bool // 必须包含特殊字符（字母、数字、空白以外的字符）
+}
+
+// 密码规则的默认值
+const (
+	defaultPasswordMinLength = 8
+	defaultRequireDigit      = true
+	defaultRequireUpper      = false
+	defaultRequireSpecial    = false
+)
+
+// GetPasswordPolicy 从指定节读取密码强度规则，读取的键名及默认值：
+//   - min_length       整数，默认 8（小于 1 视为无效）
+//   - require_digit    布尔值，默认 true
+//   - require_upper    布尔值，默认 false
+//   - require_special  布尔值，默认 false
+//
+// 各键按 GetConfig 的优先级解析（可被环境变量覆盖），值无效时使用默认值
+//
+//	[password]
+//	min_length = 12
+//	require_upper = true
+func GetPasswordPolicy(section string) PasswordPolicy {
+	policy := PasswordPolicy{
+		MinLength:      getIntConfig(section, "min_length", defaultPasswordMinLength),
+		RequireDigit:   getBoolConfig(section, "require_digit", defaultRequireDigit),
+		RequireUpper:   getBoolConfig(section, "require_upper", defaultRequireUpper),
+		RequireSpecial: getBoolConfig(section, "require_special", defaultRequireSpecial),
+	}
+	if policy.MinLength < 1 {
+		warnInvalidValue(section, "min_length", fmt.Sprint(policy.MinLength), fmt.Errorf("最小长度必须大于 0"))
+		policy.MinLength = defaultPasswordMinLength
+	}
+	return policy
+}
+
+// Validate 检查密码是否满足规则，返回列出全部未满足项的错误，满足时返回 nil
+func (p PasswordPolicy) Validate(password string) error {
+	var hasDigit, hasUpper, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	var problems []string
+	if utf8.RuneCountInString(password) < p.MinLength {
+		problems = append(problems, fmt.Sprintf("长度不能少于 %d 个字符", p.MinLength))
+	}
+	if p.RequireDigit && !hasDigit {
+		problems = append(problems, "必须包含数字")
+	}
+	if p.RequireUpper && !hasUpper {
+		problems = append(problems, "必须包含大写字母")
+	}
+	if p.RequireSpecial && !hasSpecial {
+		problems = append(problems, "必须包含特殊字符")
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("密码不符合要求：" + strings.Join(problems, "、"))
+}
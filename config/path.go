@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// 辅助函数：获取路径配置（如 log_path = ~/logs），展开 ~ / ~user 为用户主目录并执行 filepath.Clean
+// 键不存在时返回同样经过展开和清理的默认值；空值原样返回
+func getPathConfig(section, key, defaultValue string) string {
+	value, exists := lookup(section, key)
+	if !exists {
+		value = defaultValue
+	}
+	return cleanPath(expandHome(strings.TrimSpace(value)))
+}
+
+// 辅助函数：获取路径配置并转换为绝对路径（相对路径基于当前工作目录），其余规则同 getPathConfig
+func getAbsPathConfig(section, key, defaultValue string) string {
+	path := getPathConfig(section, key, defaultValue)
+	if path == "" {
+		return path
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		fmt.Printf("警告：配置项 [%s] %s 的路径 %q 无法转换为绝对路径: %v\n", section, key, path, err)
+		return path
+	}
+	return abs
+}
+
+// 辅助函数：获取相对于配置文件所在目录的路径配置（如 assets = ./static 指向配置文件旁的 static 目录）
+// 相对路径基于 ConfigFilePath 的目录拼接，绝对路径（含 ~ 展开后）原样返回，其余规则同 getPathConfig
+// 没有配置文件（如从 URL 加载或未找到文件）时相对路径保持不变，即仍相对于当前工作目录
+func getPathConfigRelativeToFile(section, key, defaultValue string) string {
+	path := getPathConfig(section, key, defaultValue)
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	filePath := ConfigFilePath()
+	if filePath == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(filePath), path)
+}
+
+// 展开路径开头的 ~（当前用户）或 ~user（指定用户）；无法获取主目录时原样返回
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+
+	name, rest := path[1:], ""
+	if idx := strings.IndexAny(name, `/\`); idx >= 0 {
+		name, rest = name[:idx], name[idx:]
+	}
+
+	var home string
+	if name == "" {
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Printf("警告：无法获取当前用户主目录，路径 %q 未展开: %v\n", path, err)
+			return path
+		}
+		home = dir
+	} else {
+		u, err := user.Lookup(name)
+		if err != nil {
+			fmt.Printf("警告：无法获取用户 %s 的主目录，路径 %q 未展开: %v\n", name, path, err)
+			return path
+		}
+		home = u.HomeDir
+	}
+	return home + rest
+}
+
+// 清理路径；空路径原样返回（filepath.Clean 会把空路径变成 "."）
+func cleanPath(path string) string {
+	if path == "" {
+		return path
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,264 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 通过 Set 修改过、尚未 Save 的键（读写均需持有 configMu）
+var dirtyKeys = make(map[[2]string]bool)
+
+// Set 在运行时修改配置文件层中的值（节不存在时自动创建），立即对 GetConfig 等读取函数生效并通知订阅者
+// 环境变量、命令行参数与覆盖层仍然优先；修改只保存在内存中，调用 Save 才会写回配置文件，
+// 在此之前调用 Load / Reload 会丢弃未保存的修改
+func Set(section, key, value string) {
+	commitWithLock(func() error {
+		setLocked(section, key, value)
+		return nil
+	})
+}
+
+// 修改配置文件层中的单个值并通知订阅者（调用方需持有 configMu 写锁）
+func setLocked(section, key, value string) {
+	result := cloneParseResult(fileResult)
+	if result.sections[section] == nil {
+		result.sections[section] = make(map[string]string)
+		result.stats.sections++
+	}
+	if _, exists := result.sections[section][key]; !exists {
+		result.order[section] = append(result.order[section], key)
+		result.stats.keys++
+	}
+	result.sections[section][key] = value
+	delete(result.literal, [2]string{section, key}) // Set 的值是普通值，不再是 @file 引用的内容
+
+	previous := config
+	commitLocked(configFilePath, result)
+	dirtyKeys[[2]string{section, key}] = true
+	notifySubscribers(previous)
+}
+
+// Save 将 Set 修改过的键写回当前配置文件，只改动这些键所在的行，其余内容（注释、空行、键顺序、格式）逐字节保留
+// 文件中已有的键原地替换值；文件中没有的键追加到对应节的末尾，节不存在时在文件末尾新建该节
+// 写入先落到同目录的临时文件再重命名，失败时原文件保持不变；没有待保存的修改时不做任何事
+// 当前配置不是来自本地 INI 文件（如 LoadFromURL 或 JSON 文件）时返回错误
+func Save() error {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if len(dirtyKeys) == 0 {
+		return nil
+	}
+	if configFilePath == "" {
+		return errors.New("当前配置不是从本地文件加载的，无法保存")
+	}
+
+	data, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\ufeff"))), []byte("{")) {
+		return errors.New("暂不支持写回 JSON 格式的配置文件")
+	}
+
+	keys := make([][2]string, 0, len(dirtyKeys))
+	for k := range dirtyKeys {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	content := string(data)
+	for _, k := range keys {
+		content = setIniValue(content, k[0], k[1], fileResult.sections[k[0]][k[1]])
+	}
+
+	if err := writeFileAtomic(configFilePath, []byte(content)); err != nil {
+		return err
+	}
+	dirtyKeys = make(map[[2]string]bool)
+	return nil
+}
+
+// 在 INI 文本中设置一个键的值：找到节内的键则原地替换（保留 = 前的内容与 = 后的空白），否则追加到该节最后一个生效的块的末尾
+// 保持文件原有的换行符（\n 或 \r\n）
+func setIniValue(content, section, key, value string) string {
+	crlf := strings.Contains(content, "\r\n")
+	lines := strings.Split(content, "\n")
+	// 生成要写入的行（CRLF 文件中每行保留 \r）
+	newLines := func(text string) []string {
+		parts := strings.Split(text, "\n")
+		if crlf {
+			for i := range parts {
+				parts[i] += "\r"
+			}
+		}
+		return parts
+	}
+
+	// 解析时同名节会被合并、后出现的键覆盖先出现的，因此要扫描目标节的所有块，替换最后一次出现的键
+	// 带 @if 条件的块只有条件成立时才参与合并，条件不成立（或无法判断）的块跳过
+	inSection := false
+	lastLine := -1                 // 目标节（最后一个块）中最后一个非空行
+	matchStart, matchEnd := -1, -1 // 键最后一次出现的行范围（含多行值）
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if name, condition, ok := parseSectionHeader(line); ok {
+			inSection = name == section
+			if inSection && condition != "" {
+				inSection, _ = sectionConditionHolds(condition)
+			}
+			if inSection {
+				lastLine = i
+			}
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			if inSection {
+				lastLine = i
+			}
+			continue
+		}
+
+		// 多行值占据到结束标记行为止（其他节的多行值同样跳过，避免把其中的内容当作节标题）
+		end := i
+		eq := strings.Index(line, "=")
+		if eq >= 0 {
+			if terminator, ok := heredocTerminator(strings.TrimSpace(line[eq+1:])); ok {
+				for end = i + 1; end < len(lines) && strings.TrimSpace(lines[end]) != terminator; end++ {
+				}
+				end = min(end, len(lines)-1)
+			}
+		}
+		if inSection {
+			lastLine = end
+			if eq >= 0 && strings.TrimSpace(line[:eq]) == key {
+				matchStart, matchEnd = i, end
+			}
+		}
+		i = end
+	}
+
+	if matchStart >= 0 {
+		raw := strings.TrimSuffix(lines[matchStart], "\r")
+		rawEq := strings.Index(raw, "=")
+		after := raw[rawEq+1:]
+		spacing := after[:len(after)-len(strings.TrimLeft(after, " \t"))]
+		if spacing == "" {
+			spacing = " "
+		}
+		replacement := newLines(raw[:rawEq+1] + spacing + formatIniValue(section, value))
+		return strings.Join(append(lines[:matchStart], append(replacement, lines[matchEnd+1:]...)...), "\n")
+	}
+
+	entry := newLines(key + " = " + formatIniValue(section, value))
+	if lastLine >= 0 {
+		// 追加到目标节最后一个非空行之后
+		return strings.Join(append(lines[:lastLine+1], append(entry, lines[lastLine+1:]...)...), "\n")
+	}
+
+	// 节不存在：在文件末尾新建
+	newline := "\n"
+	if crlf {
+		newline = "\r\n"
+	}
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += newline
+	}
+	if content != "" {
+		content += newline
+	}
+	return content + "[" + section + "]" + newline + strings.Join(entry, "\n") + "\n"
+}
+
+// 按解析规则格式化要写入的值：多行值使用 heredoc，首尾有空白或可能被误解析的值加引号
+// Set 的值是普通值，以 @ 开头时写为 @@，避免重新加载时被当作文件引用（见 resolveFileRefs，[meta] 节不处理）
+func formatIniValue(section, value string) string {
+	if section != metaSection && strings.HasPrefix(value, "@") {
+		value = "@" + value
+	}
+	if strings.Contains(value, "\n") {
+		terminator := "END"
+		for i := 1; strings.Contains(value, terminator); i++ {
+			terminator = fmt.Sprintf("END%d", i)
+		}
+		return "<<" + terminator + "\n" + value + "\n" + terminator
+	}
+
+	needsQuote := value != strings.TrimSpace(value) || strings.HasPrefix(value, "<<")
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		needsQuote = true // 否则读取时外层引号会被去掉
+	}
+	if needsQuote {
+		if !strings.Contains(value, `"`) {
+			return `"` + value + `"`
+		}
+		if !strings.Contains(value, "'") {
+			return "'" + value + "'"
+		}
+	}
+	return value
+}
+
+// 先写入同目录下的临时文件再重命名，保留原文件权限
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// 深拷贝解析结果
+func cloneParseResult(result *parseResult) *parseResult {
+	clone := &parseResult{
+		sections: make(map[string]map[string]string, len(result.sections)),
+		order:    make(map[string][]string, len(result.order)),
+		stats:    result.stats,
+	}
+	if result.literal != nil {
+		clone.literal = make(map[[2]string]bool, len(result.literal))
+		for id := range result.literal {
+			clone.literal[id] = true
+		}
+	}
+	for section, values := range result.sections {
+		clone.sections[section] = make(map[string]string, len(values))
+		for key, value := range values {
+			clone.sections[section][key] = value
+		}
+	}
+	for section, keys := range result.order {
+		clone.order[section] = append([]string(nil), keys...)
+	}
+	return clone
+}
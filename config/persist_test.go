@@ -0,0 +1,133 @@
+package config
+
+import "testing"
+
+func TestSaveOnlyChangesEditedLine(t *testing.T) {
+	content := "; 应用配置\r\n[app]\r\nname = did-new ; 名称\r\nport   =  50100\r\n\r\n# 服务端\r\n[server]\r\nhost = 0.0.0.0\r\n"
+	path := loadTestConfig(t, content)
+
+	Set("app", "port", "8080")
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "; 应用配置\r\n[app]\r\nname = did-new ; 名称\r\nport   =  8080\r\n\r\n# 服务端\r\n[server]\r\nhost = 0.0.0.0\r\n"
+	if got := readTestFile(t, path); got != want {
+		t.Errorf("保存后的文件 = %q，期望 %q", got, want)
+	}
+}
+
+func TestSaveRepeatedSection(t *testing.T) {
+	path := loadTestConfig(t, "[app]\nname = a\n\n[other]\nx = 1\n\n[app]\nport = 1\n")
+
+	Set("app", "port", "2")
+	Set("app", "debug", "true")
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[app]\nname = a\n\n[other]\nx = 1\n\n[app]\nport = 2\ndebug = true\n"
+	if got := readTestFile(t, path); got != want {
+		t.Errorf("保存后的文件 = %q，期望 %q", got, want)
+	}
+	if err := Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustLookup(t, "app", "port"); got != "2" {
+		t.Errorf("重新加载后 port = %q，期望 2", got)
+	}
+}
+
+func TestSaveHeredoc(t *testing.T) {
+	content := "[tls]\ncert = <<END\n[fake]\nport = 9\nEND\nport = 1\n\n[fake]\nport = 3\n"
+	path := loadTestConfig(t, content)
+
+	// 多行值中看起来像节标题和键的行不能被当作配置
+	Set("tls", "port", "2")
+	Set("fake", "port", "4")
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+	want := "[tls]\ncert = <<END\n[fake]\nport = 9\nEND\nport = 2\n\n[fake]\nport = 4\n"
+	if got := readTestFile(t, path); got != want {
+		t.Fatalf("保存后的文件 = %q，期望 %q", got, want)
+	}
+
+	// 多行值整体替换为单行值
+	Set("tls", "cert", "none")
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+	want = "[tls]\ncert = none\nport = 2\n\n[fake]\nport = 4\n"
+	if got := readTestFile(t, path); got != want {
+		t.Errorf("保存后的文件 = %q，期望 %q", got, want)
+	}
+}
+
+func TestSaveNewSection(t *testing.T) {
+	path := loadTestConfig(t, "[app]\nname = a")
+
+	Set("cache", "ttl", " 5m ")
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+	want := "[app]\nname = a\n\n[cache]\nttl = \" 5m \"\n"
+	if got := readTestFile(t, path); got != want {
+		t.Errorf("保存后的文件 = %q，期望 %q", got, want)
+	}
+}
+
+func TestSaveAtValueRoundTrip(t *testing.T) {
+	path := loadTestConfig(t, "[app]\nname = did-new\n")
+
+	Set("app", "handle", "@bob")
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, path); got != "[app]\nname = did-new\nhandle = @@bob\n" {
+		t.Errorf("以 @ 开头的值应写为 @@，实际文件 %q", got)
+	}
+	if err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := mustLookup(t, "app", "handle"); got != "@bob" {
+		t.Errorf("重新加载后 handle = %q，期望 @bob", got)
+	}
+}
+
+func TestSaveSkipsInactiveConditionalSection(t *testing.T) {
+	t.Setenv("APP_ENV", "dev")
+	path := loadTestConfig(t, "[app]\nport = 1\n\n[app @if APP_ENV=prod]\nport = 2\n")
+
+	Set("app", "port", "3")
+	Set("app", "debug", "true")
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[app]\nport = 3\ndebug = true\n\n[app @if APP_ENV=prod]\nport = 2\n"
+	if got := readTestFile(t, path); got != want {
+		t.Errorf("保存后的文件 = %q，期望 %q", got, want)
+	}
+	if err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustLookup(t, "app", "port"); got != "3" {
+		t.Errorf("重新加载后 port = %q，期望 3", got)
+	}
+}
+
+func TestSaveActiveConditionalSection(t *testing.T) {
+	t.Setenv("APP_ENV", "prod")
+	path := loadTestConfig(t, "[app]\nport = 1\n\n[app @if APP_ENV=prod]\nport = 2\n")
+
+	Set("app", "port", "3")
+	if err := Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[app]\nport = 1\n\n[app @if APP_ENV=prod]\nport = 3\n"
+	if got := readTestFile(t, path); got != want {
+		t.Errorf("保存后的文件 = %q，期望 %q", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+// Provider 配置来源接口：返回指定节/键的值以及该键是否存在
+// 可用于接入 etcd、Consul 等配置中心；Get 在持有包内读锁时被调用，不应回调本包的读取函数
+type Provider interface {
+	Get(section, key string) (string, bool)
+}
+
+// 默认的文件配置来源：包装解析后的配置文件内容
+type fileProvider struct{}
+
+func (fileProvider) Get(section, key string) (string, bool) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return lookupFile(section, key)
+}
+
+// 环境变量之后依次查询的配置来源（读写均需持有 configMu），默认只有配置文件
+var providers = []Provider{fileProvider{}}
+
+// FileProvider 返回默认的文件配置来源，可配合 SetProviders 调整其在查找链中的位置
+func FileProvider() Provider {
+	return fileProvider{}
+}
+
+// RegisterProvider 注册额外的配置来源：位于环境变量之后、配置文件之前，多个来源按注册顺序查询
+// 若已通过 SetProviders 去掉文件来源，则追加到查找链末尾
+func RegisterProvider(p Provider) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	for i, existing := range providers {
+		if _, isFile := existing.(fileProvider); isFile {
+			providers = append(providers[:i], append([]Provider{p}, providers[i:]...)...)
+			return
+		}
+	}
+	providers = append(providers, p)
+}
+
+// SetProviders 整体替换环境变量之后的查找链，用于自定义文件来源的位置（或完全去掉文件来源）
+// 示例：config.SetProviders(config.FileProvider(), consulProvider) 让配置文件优先于 Consul
+func SetProviders(ps ...Provider) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	providers = append([]Provider(nil), ps...)
+}
+
+// 按顺序查询配置来源（调用方需持有 configMu 读锁）
+func lookupProvidersLocked(section, key string) (string, bool) {
+	value, _, exists := lookupProvidersSourceLocked(section, key)
+	return value, exists
+}
+
+// 同 lookupProvidersLocked，并返回值的来源：配置文件中 @file 引用的内容为 sourceLiteral，其余为 sourceProvider
+func lookupProvidersSourceLocked(section, key string) (string, valueSource, bool) {
+	for _, p := range providers {
+		if _, isFile := p.(fileProvider); isFile {
+			// 已持有读锁，直接查找，避免重复加锁
+			if value, literal, exists := lookupFileEntry(section, key); exists {
+				if literal {
+					return value, sourceLiteral, true
+				}
+				return value, sourceProvider, true
+			}
+			continue
+		}
+		if value, exists := p.Get(section, key); exists {
+			return value, sourceProvider, true
+		}
+	}
+	return "", 0, false
+}
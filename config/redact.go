@@ -0,0 +1,75 @@
+package config
+
+import (
+	"strings"
+	"sync"
+)
+
+// 脱敏后显示的占位符
+const redactedValue = "***"
+
+// 标记为敏感的节：所有输出路径中其下的值都被脱敏
+var (
+	secretMu       sync.RWMutex
+	secretSections = make(map[string]bool)
+	redactor       func(section, key, value string) string // 自定义脱敏函数（见 SetRedactor），nil 时使用 defaultRedactor
+)
+
+// 键名中包含以下片段（忽略大小写）时视为敏感键，默认脱敏
+var sensitiveKeyParts = []string{"password", "passwd", "secret", "token", "api_key", "apikey", "private_key", "credential"}
+
+// MarkSectionSecret 将整个节标记为敏感（如 [secrets]），PrintAllConfigs、GetAll、DumpJSON 中
+// 该节的所有值默认显示为 "***"；比逐个键脱敏更粗粒度，适合专门存放密钥的节
+func MarkSectionSecret(section string) {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secretSections[section] = true
+}
+
+// SetRedactor 设置自定义脱敏函数，替代默认规则（敏感节及敏感键名的值整体显示为 "***"），
+// 例如只显示令牌的前几个字符或输出哈希值；PrintAllConfigs、GetAll、DumpJSON、PrecedenceReport 等所有输出路径都会调用它
+// 函数对每个待输出的值调用一次，返回值即为显示内容；它可能在持有包内配置锁时被调用，
+// 不得 panic，也不应回调本包的读取函数；可以调用 DefaultRedact 复用默认规则；传入 nil 恢复默认规则
+func SetRedactor(fn func(section, key, value string) string) {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	redactor = fn
+}
+
+// DefaultRedact 默认脱敏规则：敏感节（见 MarkSectionSecret）中的值以及键名含 password、token、secret 等的值显示为 "***"，其余原样返回
+func DefaultRedact(section, key, value string) string {
+	secretMu.RLock()
+	defer secretMu.RUnlock()
+	return defaultRedactorLocked(section, key, value)
+}
+
+// 默认脱敏规则（调用方需持有 secretMu 读锁）
+func defaultRedactorLocked(section, key, value string) string {
+	if secretSections[section] || isSensitiveKey(key) {
+		return redactedValue
+	}
+	return value
+}
+
+// 判断键名是否为敏感键
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// 按脱敏规则处理待输出的值
+func redactValue(section, key, value string) string {
+	secretMu.RLock()
+	fn := redactor
+	secretMu.RUnlock()
+
+	if fn == nil {
+		return DefaultRedact(section, key, value)
+	}
+	return fn(section, key, value)
+}
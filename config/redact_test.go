@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretSectionMaskedInAllOutputs(t *testing.T) {
+	MarkSectionSecret("docker")
+	t.Cleanup(func() {
+		secretMu.Lock()
+		delete(secretSections, "docker")
+		secretMu.Unlock()
+	})
+	loadTestConfig(t, "[docker]\nimage_name = internal-image\n[app]\nname = visible\n")
+
+	all := GetAll()
+	if got := all["docker"]["image_name"]; got != redactedValue {
+		t.Errorf("GetAll [docker] image_name = %q, want %q", got, redactedValue)
+	}
+	if got := all["app"]["name"]; got != "visible" {
+		t.Errorf("GetAll [app] name = %q, want visible", got)
+	}
+
+	dump, err := DumpJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(dump), "internal-image") || !strings.Contains(string(dump), redactedValue) {
+		t.Errorf("DumpJSON 未脱敏敏感节:\n%s", dump)
+	}
+
+	out := captureStdout(t, PrintAllConfigs)
+	if strings.Contains(out, "internal-image") || !strings.Contains(out, "DOCKER_IMAGE_NAME: "+redactedValue) {
+		t.Errorf("PrintAllConfigs 未脱敏敏感节:\n%s", out)
+	}
+	if !strings.Contains(out, "APP_NAME: visible") {
+		t.Errorf("PrintAllConfigs 不应脱敏普通节:\n%s", out)
+	}
+}
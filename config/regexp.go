@@ -0,0 +1,45 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// 已编译正则缓存：key 为原始配置值，配置重新加载时清空
+var (
+	regexpMu    sync.Mutex
+	regexpCache = make(map[string]*regexp.Regexp)
+)
+
+// 辅助函数：获取正则表达式配置（如 ignore_pattern = ^/health$），编译结果按原始值缓存
+// 适合在请求路径中反复调用；编译失败时打印警告并返回默认值
+func getRegexpConfig(section, key string, defaultValue *regexp.Regexp) *regexp.Regexp {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+	value = strings.TrimSpace(value)
+
+	regexpMu.Lock()
+	defer regexpMu.Unlock()
+
+	if re, cached := regexpCache[value]; cached {
+		return re
+	}
+
+	re, err := regexp.Compile(value)
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	regexpCache[value] = re
+	return re
+}
+
+// 清空已编译正则缓存（配置重新加载时调用）
+func clearRegexpCache() {
+	regexpMu.Lock()
+	defer regexpMu.Unlock()
+	regexpCache = make(map[string]*regexp.Regexp)
+}
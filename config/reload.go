@@ -0,0 +1,204 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// Load 从指定文件加载配置，替换当前全部文件配置，并运行已注册的校验器
+// 整个过程是事务性的：先解析到临时结果，校验通过后才整体生效
+// 解析失败返回解析错误、校验失败返回 ValidationErrors，两种情况下当前配置都保持不变
+func Load(path string) error {
+	result, err := parseIniFile(path)
+	if err != nil {
+		logLoadEvent(path, nil, err)
+		return err
+	}
+	if err := checkEmptyConfig(path, result); err != nil {
+		currentLogger().Warn("配置文件为空", "path", path, "error", err)
+	}
+
+	err = commitWithLock(func() error {
+		return commitValidatedLocked(path, result)
+	})
+	logLoadEvent(path, result, err)
+	return err
+}
+
+// LoadWithFallback 先尝试 Load 主配置文件，解析或校验失败时记录警告并改为加载备用（已知可用的）配置文件
+// 主配置成功时不会读取备用文件；最终生效的文件可通过 ConfigFilePath 查询
+// 两个文件都加载失败时返回合并后的错误，当前配置保持不变
+func LoadWithFallback(primary, fallback string) error {
+	err := Load(primary)
+	if err == nil {
+		return nil
+	}
+
+	currentLogger().Warn("主配置文件加载失败，改用备用配置文件", "path", primary, "fallback", fallback, "error", err)
+	if fallbackErr := Load(fallback); fallbackErr != nil {
+		return errors.Join(err, fallbackErr)
+	}
+	return nil
+}
+
+// 持有 configMu 写锁执行 commit，成功后刷新 APP_PORT 等常用配置变量（见 loadCachedVars）
+// 常用配置变量的读取函数需要加读锁，因此只能在释放写锁之后刷新
+func commitWithLock(commit func() error) error {
+	configMu.Lock()
+	err := commit()
+	configMu.Unlock()
+	if err == nil {
+		loadCachedVars()
+	}
+	return err
+}
+
+// 提交解析结果并运行校验器，校验失败时回滚到之前的配置（调用方需持有 configMu 写锁）
+func commitValidatedLocked(path string, result *parseResult) error {
+	return commitCheckedLocked(path, result, validateLocked)
+}
+
+// 提交解析结果并运行 check，check 返回错误时回滚到之前的配置（调用方需持有 configMu 写锁）
+// 配置中包含未允许的节（见 SetAllowedSections）时直接返回错误，不提交
+func commitCheckedLocked(path string, result *parseResult, check func() []error) error {
+	if err := checkAllowedSectionsLocked(result); err != nil {
+		return err
+	}
+
+	previous := snapshotLocked()
+	previousPath := configFilePath
+	previousConfig := config
+	commitLocked(path, result)
+
+	if errs := check(); len(errs) > 0 {
+		commitLocked(previousPath, previous)
+		return ValidationErrors(errs)
+	}
+
+	dirtyKeys = make(map[[2]string]bool) // 未保存的 Set 修改随旧配置一起丢弃
+	notifySubscribers(previousConfig)
+	return nil
+}
+
+// Reload 重新加载当前配置文件（未加载过文件时重新查找配置文件路径）
+// 每次调用（无论成功与否）都会记录到重载历史中，见 ReloadCount、LastReloadTime、LastReloadError
+func Reload() error {
+	err := reload()
+	recordReload(err)
+	return err
+}
+
+// ReloadValidated 与 Reload 相同，但新配置除了已注册的校验器外还必须通过 schema 的校验（规则同 Schema.Validate，
+// 按生效值校验，含环境变量覆盖），否则返回 ValidationErrors 并保留之前的配置，避免热加载引入无效配置
+// 同样记录到重载历史中
+func ReloadValidated(schema Schema) error {
+	err := reloadValidated(schema)
+	recordReload(err)
+	return err
+}
+
+func reload() error {
+	path, err := reloadPath()
+	if err != nil {
+		return err
+	}
+	return Load(path)
+}
+
+func reloadValidated(schema Schema) error {
+	path, err := reloadPath()
+	if err != nil {
+		return err
+	}
+	result, err := parseIniFile(path)
+	if err != nil {
+		logLoadEvent(path, nil, err)
+		return err
+	}
+	if err := checkEmptyConfig(path, result); err != nil {
+		currentLogger().Warn("配置文件为空", "path", path, "error", err)
+	}
+
+	err = commitWithLock(func() error {
+		return commitCheckedLocked(path, result, func() []error {
+			return append(schema.validate(lookupLocked), validateLocked()...)
+		})
+	})
+	logLoadEvent(path, result, err)
+	return err
+}
+
+// 重载的目标路径：当前配置文件，未加载过文件时重新查找
+func reloadPath() (string, error) {
+	configMu.RLock()
+	path := configFilePath
+	configMu.RUnlock()
+
+	if path == "" {
+		return getConfigFilePath()
+	}
+	return path, nil
+}
+
+// 记录一次重载（见 ReloadCount 等）
+func recordReload(err error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	reloadCount++
+	lastReloadTime = time.Now()
+	lastReloadErr = err
+}
+
+// 重载历史（见 Reload）
+var (
+	reloadMu       sync.Mutex
+	reloadCount    int
+	lastReloadTime time.Time
+	lastReloadErr  error
+)
+
+// ReloadCount 返回 Reload 被调用的次数（包括失败的重载，以及 PollConfig、ReloadOnSignal 触发的重载）
+// 可用于健康检查确认热加载是否在工作
+func ReloadCount() int {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	return reloadCount
+}
+
+// LastReloadTime 返回最近一次 Reload 的时间，从未重载时返回零值
+func LastReloadTime() time.Time {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	return lastReloadTime
+}
+
+// LastReloadError 返回最近一次 Reload 的错误，成功（或从未重载）时返回 nil
+func LastReloadError() error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	return lastReloadErr
+}
+
+// ConfigFilePath 返回当前生效的配置文件路径（未找到配置文件时为空字符串）
+func ConfigFilePath() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return configFilePath
+}
+
+// ConfigFileModTime 返回当前配置文件的最后修改时间，供下游缓存判断是否需要重新计算
+// 未解析到配置文件时返回错误
+func ConfigFileModTime() (time.Time, error) {
+	path := ConfigFilePath()
+	if path == "" {
+		return time.Time{}, errors.New("未解析到配置文件路径")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// 未闭合的多行值总是解析错误
+const malformedConfig = "[app]\nport = 2\ncert = <<END\n-----BEGIN CERTIFICATE-----\n"
+
+func TestReloadMalformedKeepsConfig(t *testing.T) {
+	path := loadTestConfig(t, "[app]\nname = did-new\nport = 1\n")
+
+	if err := os.WriteFile(path, []byte(malformedConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(); err == nil {
+		t.Fatal("Reload 解析失败时应返回错误")
+	}
+
+	if got := mustLookup(t, "app", "port"); got != "1" {
+		t.Errorf("port = %q，期望保持 1", got)
+	}
+	if got := mustLookup(t, "app", "name"); got != "did-new" {
+		t.Errorf("name = %q，期望保持 did-new", got)
+	}
+	if _, exists := lookup("app", "cert"); exists {
+		t.Error("失败的重载不应留下部分解析的键")
+	}
+	if got := ConfigFilePath(); got != path {
+		t.Errorf("ConfigFilePath = %q，期望 %q", got, path)
+	}
+	if LastReloadError() == nil {
+		t.Error("LastReloadError 应记录失败原因")
+	}
+}
+
+func TestCheckEmptyConfig(t *testing.T) {
+	tests := []struct {
+		name, content string
+		empty         bool
+	}{
+		{"空文件", "", true},
+		{"只有 BOM", "\ufeff", true},
+		{"BOM 加空白", "\ufeff \n\t\n\r\n", true},
+		{"只有注释", "; comment\n# another\n", true},
+		{"只有节标题", "[app]\n", false},
+		{"BOM 后有键", "\ufeff[app]\nname = x\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestFile(t, "config.ini", tt.content)
+			result, err := parseIniFile(path)
+			if err != nil {
+				t.Fatalf("parseIniFile: %v", err)
+			}
+			if err := checkEmptyConfig(path, result); (err != nil) != tt.empty {
+				t.Errorf("checkEmptyConfig = %v, 期望为空: %v", err, tt.empty)
+			}
+		})
+	}
+}
+
+func TestLoadEmptyFileWarns(t *testing.T) {
+	log := useRecordingLogger(t)
+	loadTestConfig(t, "\ufeff\n\n")
+	if !log.has("WARN 配置文件为空") {
+		t.Error("加载空配置文件应记录警告")
+	}
+}
+
+func TestReloadValidatedRejectsInvalidConfig(t *testing.T) {
+	path := loadTestConfig(t, "[app]\nport = 8080\nmode = prod\n")
+	maxPort := 65535.0
+	schema := Schema{Keys: []KeySchema{
+		{Section: "app", Key: "port", Type: TypeInt, Required: true, Max: &maxPort},
+		{Section: "app", Key: "mode", Enum: []string{"dev", "prod"}},
+	}}
+
+	// 能正常解析，但 port 超出范围、mode 不在允许的取值中
+	if err := os.WriteFile(path, []byte("[app]\nport = 70000\nmode = staging\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := ReloadValidated(schema)
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 2 {
+		t.Fatalf("ReloadValidated 应返回两个校验错误，实际 %v", err)
+	}
+	if got := mustLookup(t, "app", "port"); got != "8080" {
+		t.Errorf("校验失败后 port = %q，期望保持 8080", got)
+	}
+	if APP_PORT != 8080 {
+		t.Errorf("校验失败后 APP_PORT = %d，期望保持 8080", APP_PORT)
+	}
+	if !errors.As(LastReloadError(), &verrs) {
+		t.Errorf("LastReloadError = %v，期望为本次的校验错误", LastReloadError())
+	}
+
+	if err := os.WriteFile(path, []byte("[app]\nport = 9090\nmode = dev\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReloadValidated(schema); err != nil {
+		t.Fatalf("合法配置的 ReloadValidated: %v", err)
+	}
+	if got := mustLookup(t, "app", "port"); got != "9090" {
+		t.Errorf("port = %q, want 9090", got)
+	}
+}
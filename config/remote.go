@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 远程配置内容大小上限，超过时视为拉取失败（不截断）
+const maxRemoteBodySize = 10 << 20
+
+// 远程加载选项（见 SetHTTPLoadOptions）
+var (
+	remoteMu        sync.Mutex
+	httpLoadTimeout = 10 * time.Second
+	httpBearerToken string
+)
+
+// SetHTTPLoadOptions 设置 LoadFromURL 的请求超时与可选的 Bearer Token（为空时不发送 Authorization 头）
+func SetHTTPLoadOptions(timeout time.Duration, bearerToken string) {
+	remoteMu.Lock()
+	defer remoteMu.Unlock()
+	httpLoadTimeout = timeout
+	httpBearerToken = bearerToken
+}
+
+// LoadFromURL 通过 HTTP(S) 从配置中心拉取配置并替换当前文件配置（TLS 证书校验默认开启）
+// 格式按响应的 Content-Type（application/json）或 URL 扩展名（.json）判断，两者都不是 JSON 时按内容判断（见 parseConfigData）
+// 响应超过 10MB 时视为拉取失败
+// 拉取或解析失败时记录警告并回退到本地配置文件（不计入 ReloadCount 等重载历史）；本地文件也加载失败时返回错误
+// 远程配置中以 @ 开头的值按普通值处理，不会读取本机文件（见 resolveFileRefs）
+// 远程配置生效后 ConfigFilePath 为空，之后调用 Reload 会重新加载本地配置文件
+func LoadFromURL(rawURL string) error {
+	result, err := fetchConfig(rawURL)
+	if err == nil {
+		if emptyErr := checkEmptyConfig(rawURL, result); emptyErr != nil {
+			currentLogger().Warn("配置文件为空", "path", rawURL, "error", emptyErr)
+		}
+		err = commitWithLock(func() error {
+			return commitValidatedLocked("", result)
+		})
+		logLoadEvent(rawURL, result, err)
+		return err
+	}
+
+	logLoadEvent(rawURL, nil, err)
+	currentLogger().Warn("远程配置加载失败，回退到本地配置文件", "url", rawURL)
+	if reloadErr := reload(); reloadErr != nil { // 回退不是用户触发的重载，不计入重载历史（见 ReloadCount）
+		return errors.Join(err, reloadErr)
+	}
+	return nil
+}
+
+// 拉取并解析远程配置
+func fetchConfig(rawURL string) (*parseResult, error) {
+	remoteMu.Lock()
+	timeout, token := httpLoadTimeout, httpBearerToken
+	remoteMu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP 状态码 %d", resp.StatusCode)
+	}
+
+	// 多读一个字节，以区分恰好等于上限和超过上限
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxRemoteBodySize {
+		return nil, fmt.Errorf("远程配置超过 %d 字节上限", maxRemoteBodySize)
+	}
+
+	if isJSONSource(rawURL, resp.Header.Get("Content-Type")) {
+		return parseJSONConfig(data)
+	}
+	return parseConfigData(data)
+}
+
+// 根据 Content-Type 或 URL 扩展名判断是否为 JSON 配置
+func isJSONSource(rawURL, contentType string) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			return true
+		}
+	}
+	if u, err := url.Parse(rawURL); err == nil {
+		return strings.EqualFold(path.Ext(u.Path), ".json")
+	}
+	return false
+}
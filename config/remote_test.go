@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// 启动返回固定内容的 HTTP 服务
+func serveConfig(t *testing.T, contentType, body string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestLoadFromURLSniffsJSON(t *testing.T) {
+	t.Cleanup(resetTestConfig)
+	log := useRecordingLogger(t)
+
+	url := serveConfig(t, "text/plain", `{"app": {"name": "remote"}}`)
+	if err := LoadFromURL(url + "/config"); err != nil {
+		t.Fatalf("LoadFromURL: %v", err)
+	}
+	if got := mustLookup(t, "app", "name"); got != "remote" {
+		t.Errorf("[app] name = %q, want remote", got)
+	}
+	if !log.has("INFO config loaded") {
+		t.Error("成功加载后应记录 config loaded")
+	}
+}
+
+func TestLoadFromURLIni(t *testing.T) {
+	t.Cleanup(resetTestConfig)
+
+	url := serveConfig(t, "application/octet-stream", "[app]\nname = remote-ini\n")
+	if err := LoadFromURL(url); err != nil {
+		t.Fatalf("LoadFromURL: %v", err)
+	}
+	if got := mustLookup(t, "app", "name"); got != "remote-ini" {
+		t.Errorf("[app] name = %q, want remote-ini", got)
+	}
+}
+
+func TestLoadFromURLEmptyWarns(t *testing.T) {
+	t.Cleanup(resetTestConfig)
+	log := useRecordingLogger(t)
+
+	url := serveConfig(t, "text/plain", "\n")
+	if err := LoadFromURL(url); err != nil {
+		t.Fatalf("LoadFromURL: %v", err)
+	}
+	if !log.has("WARN 配置文件为空") {
+		t.Error("空的远程配置应记录警告")
+	}
+}
+
+func TestFetchConfigRejectsOversizedBody(t *testing.T) {
+	body := "[app]\nname = x\n" + strings.Repeat("#", maxRemoteBodySize)
+	url := serveConfig(t, "text/plain", body)
+	if _, err := fetchConfig(url); err == nil {
+		t.Fatal("超过上限的响应应返回错误，而不是截断后解析")
+	}
+
+	url = serveConfig(t, "text/plain", "[app]\nname = x\n"+strings.Repeat("#", maxRemoteBodySize-len("[app]\nname = x\n")))
+	if _, err := fetchConfig(url); err != nil {
+		t.Fatalf("恰好等于上限的响应应成功: %v", err)
+	}
+}
+
+func TestLoadFromURLFallbackNotCountedAsReload(t *testing.T) {
+	path := loadTestConfig(t, "[app]\nname = local\n")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	count, lastErr := ReloadCount(), fmt.Sprint(LastReloadError())
+	if err := LoadFromURL(srv.URL); err != nil {
+		t.Fatalf("回退到本地配置文件应成功: %v", err)
+	}
+	if got := ConfigFilePath(); got != path {
+		t.Errorf("ConfigFilePath = %q, want %q", got, path)
+	}
+	if ReloadCount() != count || fmt.Sprint(LastReloadError()) != lastErr {
+		t.Errorf("URL 拉取失败的回退不应计入重载历史：ReloadCount %d → %d", count, ReloadCount())
+	}
+}
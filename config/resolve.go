@@ -0,0 +1,76 @@
+package config
+
+import "strings"
+
+// GetStringMapResolved 返回指定节全部键的值，其中的 ${key}（同一节的键）和 ${section.key}（其他节的键，按最后一个点拆分）
+// 引用被展开，引用的键本身也会被递归展开，适合由基础值组合出其他值（如 log_dir = ${base_dir}/log）
+// 引用的名称不是已有配置键时，按环境变量引用处理（规则同配置文件中的 $NAME）；
+// 只有配置文件中的值参与引用展开，命令行参数和环境变量的值按原有规则处理
+// 存在循环引用的条目（以及依赖它们的条目）返回未展开的原始值；节不存在时返回空 map
+func GetStringMapResolved(section string) map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	r := &refResolver{
+		resolved:  make(map[[2]string]string),
+		resolving: make(map[[2]string]bool),
+	}
+	result := make(map[string]string, len(config[section]))
+	for key := range config[section] {
+		if value, exists, _ := r.resolve(section, key); exists {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// 键引用解析器：记录已解析的值与正在解析的键，用于检测循环引用（调用方需持有 configMu 读锁）
+type refResolver struct {
+	resolved  map[[2]string]string
+	resolving map[[2]string]bool
+}
+
+// 解析单个键的值，第三个返回值表示该键处于（或依赖于）循环引用中
+func (r *refResolver) resolve(section, key string) (string, bool, bool) {
+	id := [2]string{section, key}
+	if value, done := r.resolved[id]; done {
+		return value, true, false
+	}
+	if r.resolving[id] {
+		return "", true, true
+	}
+
+	raw, source, exists := lookupRawLocked(section, key)
+	if !exists {
+		return "", false, false
+	}
+	if source != sourceProvider || isTemplateValue(raw) {
+		value := processSourceValue(raw, source)
+		r.resolved[id] = value
+		return value, true, false
+	}
+
+	r.resolving[id] = true
+	cyclic := false
+	value := expandRefs(raw, func(name string) (string, bool) {
+		refSection, refKey := section, name
+		if idx := strings.LastIndex(name, "."); idx >= 0 {
+			refSection, refKey = name[:idx], name[idx+1:]
+		}
+		refValue, refExists, refCyclic := r.resolve(refSection, refKey)
+		if refCyclic {
+			cyclic = true
+		}
+		if refExists {
+			return refValue, true
+		}
+		return lookupEnvRef(name)
+	})
+	delete(r.resolving, id)
+
+	if cyclic {
+		return raw, true, true
+	}
+	r.resolved[id] = value
+	return value, true, false
+}
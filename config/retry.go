@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryPolicy 常见的重试配置（HTTP 客户端等）
+type RetryPolicy struct {
+	MaxRetries int           // 最大重试次数
+	Backoff    time.Duration // 两次重试之间的等待时间
+	Timeout    time.Duration // 单次请求超时
+}
+
+// 重试配置的默认值
+const (
+	defaultMaxRetries = 3
+	defaultBackoff    = 500 * time.Millisecond
+	defaultTimeout    = 10 * time.Second
+)
+
+// GetRetryPolicy 从指定节读取重试配置，读取的键名及默认值：
+//   - max_retries  整数，默认 3（负数视为无效）
+//   - backoff      时长（如 500ms、2s），默认 500ms
+//   - timeout      时长，默认 10s
+//
+// 各键按 GetConfig 的优先级解析（可被环境变量覆盖），值无效时打印警告并使用默认值
+//
+//	[http_client]
+//	max_retries = 5
+//	backoff = 1s
+//	timeout = 30s
+func GetRetryPolicy(section string) RetryPolicy {
+	policy := RetryPolicy{
+		MaxRetries: getCheckedIntConfig(section, "max_retries", defaultMaxRetries),
+		Backoff:    getDurationConfig(section, "backoff", defaultBackoff),
+		Timeout:    getDurationConfig(section, "timeout", defaultTimeout),
+	}
+	if policy.MaxRetries < 0 {
+		warnInvalidValue(section, "max_retries", fmt.Sprint(policy.MaxRetries), fmt.Errorf("重试次数不能为负数"))
+		policy.MaxRetries = defaultMaxRetries
+	}
+	return policy
+}
+
+// 退避配置的默认值
+const (
+	defaultBackoffBase   = 100 * time.Millisecond
+	defaultBackoffFactor = 2.0
+	defaultBackoffMax    = 30 * time.Second
+)
+
+// GetBackoff 从指定节读取指数退避配置，返回依次产生等待时间的函数：第一次返回 base，之后每次乘以 factor，最大不超过 max
+// 读取的键名及默认值：
+//   - base    时长，默认 100ms
+//   - factor  增长倍数（不小于 1 的浮点数），默认 2
+//   - max     时长上限，默认 30s（小于 base 时以 base 为准）
+//
+// 每次调用 GetBackoff 返回一个独立的、有状态的函数（非并发安全），配置只在调用 GetBackoff 时读取一次
+//
+//	next := config.GetBackoff("http_client")
+//	for attempt := 0; attempt < maxRetries; attempt++ {
+//		time.Sleep(next())
+//	}
+func GetBackoff(section string) func() time.Duration {
+	base := getDurationConfig(section, "base", defaultBackoffBase)
+	factor := getFloatConfig(section, "factor", defaultBackoffFactor)
+	if factor < 1 {
+		warnInvalidValue(section, "factor", fmt.Sprint(factor), fmt.Errorf("增长倍数不能小于 1"))
+		factor = defaultBackoffFactor
+	}
+	maxDelay := getDurationConfig(section, "max", defaultBackoffMax)
+	if maxDelay < base {
+		maxDelay = base
+	}
+
+	next := base
+	return func() time.Duration {
+		delay := next
+		if grown := float64(next) * factor; grown >= float64(maxDelay) {
+			next = maxDelay
+		} else {
+			next = time.Duration(grown)
+		}
+		return delay
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetRetryPolicyWarnsOnInvalidMaxRetries(t *testing.T) {
+	loadTestConfig(t, "[http_client]\nmax_retries = lots\n")
+
+	var policy RetryPolicy
+	out := captureStdout(t, func() { policy = GetRetryPolicy("http_client") })
+	if policy.MaxRetries != defaultMaxRetries {
+		t.Errorf("MaxRetries = %d, want %d", policy.MaxRetries, defaultMaxRetries)
+	}
+	if !strings.Contains(out, "max_retries") {
+		t.Errorf("无法解析的 max_retries 应打印警告，实际输出: %q", out)
+	}
+}
+
+func TestGetRetryPolicyValid(t *testing.T) {
+	loadTestConfig(t, "[http_client]\nmax_retries = 5\nbackoff = 1s\n")
+
+	policy := GetRetryPolicy("http_client")
+	if policy.MaxRetries != 5 || policy.Backoff.String() != "1s" || policy.Timeout != defaultTimeout {
+		t.Errorf("GetRetryPolicy = %+v", policy)
+	}
+}
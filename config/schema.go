@@ -0,0 +1,271 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 配置项的取值类型（KeySchema.Type）
+const (
+	TypeString   = "string"
+	TypeInt      = "int"
+	TypeFloat    = "float"
+	TypeBool     = "bool"
+	TypeDuration = "duration" // time.ParseDuration 格式，如 30s
+)
+
+// KeySchema 描述单个配置项：类型、是否必填以及取值约束
+type KeySchema struct {
+	Section     string
+	Key         string
+	Type        string   // 取值类型，为空时视为 TypeString
+	Required    bool     // 是否必须存在
+	Default     string   // 默认值（仅用于文档和导出，不参与读取）
+	Description string   // 说明
+	Min, Max    *float64 // 数值范围（含边界，仅对 int/float 生效），nil 表示不限制
+	Enum        []string // 允许的取值，为空表示不限制
+	Pattern     string   // 正则约束（仅对 string 生效），为空表示不限制
+}
+
+// Schema 声明的配置结构，可用于校验当前配置或导出为 JSON Schema
+type Schema struct {
+	Keys []KeySchema
+}
+
+// Validate 按声明校验当前配置（值按 GetConfig 的优先级解析），返回全部错误（无错误时返回 nil）
+// 必填项缺失、类型不符或违反约束都会记为错误；未声明的键不检查
+func (s Schema) Validate() []error {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return s.validate(lookupLocked)
+}
+
+// ValidateConfig 按声明校验独立的配置实例（只看实例中的值，不含环境变量覆盖），规则同 Validate
+func (s Schema) ValidateConfig(c *Config) []error {
+	return s.validate(c.Get)
+}
+
+// ValidateFile 加载指定配置文件到临时实例并按声明校验，返回全部问题（无问题时返回 nil），不影响全局配置
+// 文件无法读取或解析时返回只包含该错误的切片；适合实现 myapp config validate 这类 CI 检查命令：
+//
+//	if errs := config.ValidateFile(path, schema); len(errs) > 0 {
+//		for _, err := range errs {
+//			fmt.Fprintln(os.Stderr, err)
+//		}
+//		os.Exit(1)
+//	}
+func ValidateFile(path string, schema Schema) []error {
+	c, err := LoadFile(path)
+	if err != nil {
+		return []error{err}
+	}
+	return schema.ValidateConfig(c)
+}
+
+// 使用给定的读取函数逐项校验
+func (s Schema) validate(get func(section, key string) (string, bool)) []error {
+	var errs []error
+	for _, k := range s.Keys {
+		value, exists := get(k.Section, k.Key)
+		if !exists {
+			if k.Required {
+				errs = append(errs, fmt.Errorf("配置项 [%s] %s 必须设置", k.Section, k.Key))
+			}
+			continue
+		}
+		if err := k.check(value); err != nil {
+			errs = append(errs, fmt.Errorf("配置项 [%s] %s 的值 %q 无效: %w", k.Section, k.Key, value, err))
+		}
+	}
+	return errs
+}
+
+// 检查单个值是否满足类型与约束
+func (k KeySchema) check(value string) error {
+	value = strings.TrimSpace(value)
+
+	switch k.typ() {
+	case TypeInt, TypeFloat:
+		var n float64
+		if k.typ() == TypeInt {
+			i, err := strconv.Atoi(stripThousandsSeparators(value)) // 与 getIntConfig 一致，允许 10,000
+			if err != nil {
+				return fmt.Errorf("应为整数")
+			}
+			n = float64(i)
+		} else {
+			f, err := parseFloatValue(value) // 与 getFloatConfig 一致，拒绝 NaN、Inf
+			if err != nil {
+				return fmt.Errorf("应为数字")
+			}
+			n = f
+		}
+		if k.Min != nil && n < *k.Min {
+			return fmt.Errorf("不能小于 %v", *k.Min)
+		}
+		if k.Max != nil && n > *k.Max {
+			return fmt.Errorf("不能大于 %v", *k.Max)
+		}
+	case TypeBool:
+		if _, ok := parseBoolValue(value); !ok {
+			return fmt.Errorf("应为布尔值")
+		}
+	case TypeDuration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("应为时长（如 30s）")
+		}
+		if d < 0 {
+			return fmt.Errorf("时长不能为负数") // 与 getDurationConfig 一致
+		}
+	case TypeString:
+		if k.Pattern != "" {
+			re, err := regexp.Compile(k.Pattern)
+			if err != nil {
+				return fmt.Errorf("约束正则 %q 无效: %v", k.Pattern, err)
+			}
+			if !re.MatchString(value) {
+				return fmt.Errorf("不匹配 %s", k.Pattern)
+			}
+		}
+	default:
+		return fmt.Errorf("未知的类型 %q", k.Type)
+	}
+
+	if len(k.Enum) > 0 && !containsString(k.Enum, value) {
+		return fmt.Errorf("应为 %s 之一", strings.Join(k.Enum, "、"))
+	}
+	return nil
+}
+
+// 取值类型，未设置时为 string
+func (k KeySchema) typ() string {
+	if k.Type == "" {
+		return TypeString
+	}
+	return k.Type
+}
+
+// ToJSONSchema 将声明的配置结构导出为 JSON Schema（draft-07），便于生成文档或配置界面
+// 顶层对象的每个属性对应一个节，节内属性对应配置项；int → integer、float → number、bool → boolean，
+// duration 导出为带格式说明的 string；Default 与 Enum 按声明的类型转换为 JSON 原生值
+func (s Schema) ToJSONSchema() ([]byte, error) {
+	sections := make(map[string]map[string]interface{})
+	sectionRequired := make(map[string][]string)
+	var sectionOrder []string
+
+	for _, k := range s.Keys {
+		if _, exists := sections[k.Section]; !exists {
+			sections[k.Section] = make(map[string]interface{})
+			sectionOrder = append(sectionOrder, k.Section)
+		}
+
+		prop, err := k.jsonSchema()
+		if err != nil {
+			return nil, fmt.Errorf("配置项 [%s] %s: %w", k.Section, k.Key, err)
+		}
+		sections[k.Section][k.Key] = prop
+		if k.Required {
+			sectionRequired[k.Section] = append(sectionRequired[k.Section], k.Key)
+		}
+	}
+
+	properties := make(map[string]interface{}, len(sections))
+	var required []string
+	for _, section := range sectionOrder {
+		obj := map[string]interface{}{
+			"type":       "object",
+			"properties": sections[section],
+		}
+		if keys := sectionRequired[section]; len(keys) > 0 {
+			obj["required"] = keys
+			required = append(required, section)
+		}
+		properties[section] = obj
+	}
+
+	doc := map[string]interface{}{
+		"$schema":    "http://json-schema.org/draft-07/schema#",
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		doc["required"] = required
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// 单个配置项的 JSON Schema 描述
+func (k KeySchema) jsonSchema() (map[string]interface{}, error) {
+	prop := make(map[string]interface{})
+	switch k.typ() {
+	case TypeString:
+		prop["type"] = "string"
+		if k.Pattern != "" {
+			prop["pattern"] = k.Pattern
+		}
+	case TypeInt:
+		prop["type"] = "integer"
+	case TypeFloat:
+		prop["type"] = "number"
+	case TypeBool:
+		prop["type"] = "boolean"
+	case TypeDuration:
+		prop["type"] = "string"
+		prop["pattern"] = `^[-+]?(0|([0-9]*(\.[0-9]*)?(ns|us|µs|μs|ms|s|m|h))+)$`
+	default:
+		return nil, fmt.Errorf("未知的类型 %q", k.Type)
+	}
+
+	if k.Description != "" {
+		prop["description"] = k.Description
+	}
+	if k.Min != nil && (k.typ() == TypeInt || k.typ() == TypeFloat) {
+		prop["minimum"] = *k.Min
+	}
+	if k.Max != nil && (k.typ() == TypeInt || k.typ() == TypeFloat) {
+		prop["maximum"] = *k.Max
+	}
+	if k.Default != "" {
+		value, err := k.jsonValue(k.Default)
+		if err != nil {
+			return nil, fmt.Errorf("默认值 %q 无效: %w", k.Default, err)
+		}
+		prop["default"] = value
+	}
+	if len(k.Enum) > 0 {
+		enum := make([]interface{}, len(k.Enum))
+		for i, item := range k.Enum {
+			value, err := k.jsonValue(item)
+			if err != nil {
+				return nil, fmt.Errorf("枚举值 %q 无效: %w", item, err)
+			}
+			enum[i] = value
+		}
+		prop["enum"] = enum
+	}
+	return prop, nil
+}
+
+// 按声明的类型将字符串转换为 JSON 原生值
+func (k KeySchema) jsonValue(value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	switch k.typ() {
+	case TypeInt:
+		return strconv.Atoi(stripThousandsSeparators(value))
+	case TypeFloat:
+		return parseFloatValue(value)
+	case TypeBool:
+		b, ok := parseBoolValue(value)
+		if !ok {
+			return nil, fmt.Errorf("应为布尔值")
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
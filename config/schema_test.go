@@ -0,0 +1,45 @@
+package config
+
+import "testing"
+
+func TestKeySchemaCheckMatchesGetters(t *testing.T) {
+	maxLimit := 20000.0
+	tests := []struct {
+		key   KeySchema
+		value string
+		ok    bool
+	}{
+		{KeySchema{Type: TypeInt}, "10,000", true},
+		{KeySchema{Type: TypeInt}, "10_000", true},
+		{KeySchema{Type: TypeInt, Max: &maxLimit}, "30,000", false},
+		{KeySchema{Type: TypeInt}, "10,00", false},
+		{KeySchema{Type: TypeFloat}, "NaN", false},
+		{KeySchema{Type: TypeFloat}, "2.5", true},
+		{KeySchema{Type: TypeDuration}, "30s", true},
+		{KeySchema{Type: TypeDuration}, "-5s", false},
+	}
+	for _, tt := range tests {
+		if err := tt.key.check(tt.value); (err == nil) != tt.ok {
+			t.Errorf("%s 类型校验 %q = %v, 期望通过: %v", tt.key.Type, tt.value, err, tt.ok)
+		}
+	}
+}
+
+func TestSchemaValidateAgreesWithLookup(t *testing.T) {
+	loadTestConfig(t, "[app]\nlimit = 10,000\ntimeout = -5s\n")
+	schema := Schema{Keys: []KeySchema{
+		{Section: "app", Key: "limit", Type: TypeInt},
+		{Section: "app", Key: "timeout", Type: TypeDuration},
+	}}
+
+	errs := schema.Validate()
+	if len(errs) != 1 {
+		t.Fatalf("Validate = %v，期望只有 timeout 一个错误", errs)
+	}
+	if got := getIntConfig("app", "limit", 0); got != 10000 {
+		t.Errorf("getIntConfig = %d, want 10000", got)
+	}
+	if got := getDurationConfig("app", "timeout", 0); got != 0 {
+		t.Errorf("getDurationConfig = %v，负数应回退默认值", got)
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SessionConfig 登录会话 Cookie 的常用配置，字段可直接用于 http.Cookie
+type SessionConfig struct {
+	CookieName string        // Cookie 名称
+	Secure     bool          // 是否只通过 HTTPS 发送
+	MaxAge     time.Duration // 会话有效期
+	SameSite   http.SameSite // 跨站发送策略
+}
+
+// 会话配置的默认值
+const (
+	defaultCookieName    = "session"
+	defaultCookieSecure  = true
+	defaultSessionMaxAge = 24 * time.Hour
+	defaultSameSite      = "lax"
+)
+
+// SameSite 配置值与 http.SameSite 的对应关系
+var sameSiteModes = map[string]http.SameSite{
+	"lax":    http.SameSiteLaxMode,
+	"strict": http.SameSiteStrictMode,
+	"none":   http.SameSiteNoneMode,
+}
+
+// GetSessionConfig 从指定节读取会话 Cookie 配置，读取的键名及默认值：
+//   - cookie_name  字符串，默认 session
+//   - secure       布尔值，默认 true
+//   - max_age      时长（如 30m、24h），默认 24h
+//   - same_site    lax、strict 或 none（忽略大小写），默认 lax
+//
+// 各键按 GetConfig 的优先级解析（可被环境变量覆盖），值无效时打印警告并使用默认值
+// 注意：浏览器要求 SameSite=None 的 Cookie 同时设置 Secure，两者冲突时会打印警告（不会修改配置）
+//
+//	[session]
+//	cookie_name = login_sid
+//	max_age = 12h
+//	same_site = strict
+func GetSessionConfig(section string) SessionConfig {
+	session := SessionConfig{
+		CookieName: strings.TrimSpace(getStringConfig(section, "cookie_name", defaultCookieName)),
+		Secure:     getCheckedBoolConfig(section, "secure", defaultCookieSecure),
+		MaxAge:     getDurationConfig(section, "max_age", defaultSessionMaxAge),
+		SameSite:   sameSiteModes[defaultSameSite],
+	}
+	if session.CookieName == "" {
+		warnInvalidValue(section, "cookie_name", session.CookieName, fmt.Errorf("Cookie 名称不能为空"))
+		session.CookieName = defaultCookieName
+	}
+
+	sameSite := getStringConfig(section, "same_site", defaultSameSite)
+	if mode, ok := sameSiteModes[strings.ToLower(strings.TrimSpace(sameSite))]; ok {
+		session.SameSite = mode
+	} else {
+		warnInvalidValue(section, "same_site", sameSite, fmt.Errorf("应为 lax、strict 或 none"))
+	}
+
+	if session.SameSite == http.SameSiteNoneMode && !session.Secure {
+		fmt.Printf("警告：配置节 [%s] 的 same_site = none 需要同时设置 secure = true，否则浏览器会拒绝该 Cookie\n", section)
+	}
+	return session
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSessionConfigWarnsOnInvalidSecure(t *testing.T) {
+	loadTestConfig(t, "[session]\nsecure = ture\n")
+
+	var session SessionConfig
+	out := captureStdout(t, func() { session = GetSessionConfig("session") })
+	if !session.Secure {
+		t.Error("无法识别的 secure 应回退到默认值 true")
+	}
+	if !strings.Contains(out, "secure") {
+		t.Errorf("无法识别的 secure 应打印警告，实际输出: %q", out)
+	}
+}
+
+func TestGetSessionConfigValid(t *testing.T) {
+	loadTestConfig(t, "[session]\ncookie_name = sid\nsecure = off\nsame_site = Strict\n")
+
+	session := GetSessionConfig("session")
+	if session.CookieName != "sid" || session.Secure || session.SameSite != http.SameSiteStrictMode {
+		t.Errorf("GetSessionConfig = %+v", session)
+	}
+}
@@ -2,30 +2,213 @@ package config
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
-// 全局配置解析器实例
-var config = make(map[string]map[string]string)
+// 全局配置解析器实例（读写均需持有 configMu）
+var (
+	configMu sync.RWMutex
+	config   = make(map[string]map[string]string)
+
+	// 当前生效的配置文件路径（未找到文件时为空）
+	configFilePath string
+
+	// 各节中键的声明顺序（见 OrderedKeys）
+	keyOrder = make(map[string][]string)
+
+	// 最近一次提交的配置文件解析结果（未合并内嵌默认配置），Stats 取自这里
+	fileResult = &parseResult{sections: config, order: keyOrder}
+
+	// 内嵌的默认配置（见 SetEmbeddedDefault），作为文件配置之下的一层
+	embeddedDefault *parseResult
+
+	// 当前配置中值来自 @file 引用的键，读取时原样返回（见 resolveFileRefs）
+	literalKeys map[[2]string]bool
+)
+
+// 解析统计：节数、键数、跳过的无效行数
+type parseStats struct {
+	sections int
+	keys     int
+	skipped  int
+}
+
+// 配置文件解析结果：解析完成前不会触碰全局配置
+type parseResult struct {
+	sections map[string]map[string]string
+	order    map[string][]string
+	stats    parseStats
+	literal  map[[2]string]bool // 值为 @file 引用文件内容的键（不展开环境变量、不渲染模板）
+}
+
+// 用解析结果整体替换当前配置，并在其下合并内嵌默认配置和代码默认值（调用方需持有 configMu 写锁）
+func commitLocked(path string, result *parseResult) {
+	fileResult = result
+	merged := mergeParseResults(defaultsLocked(), result)
+	config = merged.sections
+	keyOrder = merged.order
+	literalKeys = merged.literal
+	configFilePath = path
+	if path != "" {
+		missingConfigWarning.Store(nil) // 已加载配置文件，不再需要启动时的未找到警告
+	}
+	clearRegexpCache()
+	warnEnvOnlyEntriesLocked(result)
+}
+
+// 以解析结果的形式获取当前配置，用于失败时回滚（调用方需持有 configMu）
+func snapshotLocked() *parseResult {
+	return fileResult
+}
+
+// 合并两份解析结果：overlay 中的键覆盖 base，键顺序为 base 的顺序加上 overlay 新增的键
+// 返回新的结果，不修改入参；统计信息取自 overlay；@file 标记随键的值一起取自最终生效的一层
+func mergeParseResults(base, overlay *parseResult) *parseResult {
+	if base == nil {
+		return overlay
+	}
 
-// 初始化配置：程序启动时加载配置文件 + 环境变量
+	merged := &parseResult{
+		sections: make(map[string]map[string]string),
+		order:    make(map[string][]string),
+		stats:    overlay.stats,
+	}
+	for _, layer := range []*parseResult{base, overlay} {
+		for section, values := range layer.sections {
+			if merged.sections[section] == nil {
+				merged.sections[section] = make(map[string]string, len(values))
+			}
+			for key, value := range values {
+				merged.sections[section][key] = value
+				id := [2]string{section, key}
+				if layer.literal[id] {
+					if merged.literal == nil {
+						merged.literal = make(map[[2]string]bool)
+					}
+					merged.literal[id] = true
+				} else {
+					delete(merged.literal, id)
+				}
+			}
+		}
+		for section, keys := range layer.order {
+			for _, key := range keys {
+				if !containsString(merged.order[section], key) {
+					merged.order[section] = append(merged.order[section], key)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// 辅助函数：判断字符串切片中是否包含指定值
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// 节名/键名是否大小写敏感（默认 true：精确匹配，与原有行为一致）
+var caseSensitive = true
+
+// 初始化配置：程序启动时加载配置文件 + 环境变量，然后为常用配置变量赋值
+// 使用 -tags config_noautoload 构建时跳过配置文件的自动加载（见 noautoload.go）
 func init() {
+	var notFound error
+	if autoLoad {
+		notFound = loadInitialConfig()
+	}
+	loadCachedVars()
+	if notFound != nil {
+		missingConfigWarning.Store(&notFound)
+	}
+}
+
+// 启动时未找到配置文件的警告：包初始化早于 main，此时还无法知道 main 是否会调用 SetEmbeddedDefault，
+// 因此推迟到初始化之后第一次读取配置时再输出；在此之前设置了内嵌默认配置或成功加载了配置文件则不再输出
+var missingConfigWarning atomic.Pointer[error]
+
+// 输出推迟的配置文件未找到警告（只输出一次）
+func flushMissingConfigWarning() {
+	if err := missingConfigWarning.Swap(nil); err != nil {
+		currentLogger().Warn("配置文件未找到，仅使用环境变量和默认值", "error", *err)
+	}
+}
+
+// 查找并加载启动时的配置文件；解析失败时只记录警告，继续使用环境变量和默认值
+// 未找到配置文件时返回该错误，由 init 推迟输出（见 missingConfigWarning）
+func loadInitialConfig() error {
 	// 获取配置文件路径（与Python逻辑一致：当前文件目录下的config.ini）
 	configFile, err := getConfigFilePath()
 	if err != nil {
-		fmt.Printf("警告：获取配置文件路径失败，仅使用环境变量和默认值: %v\n", err)
-		return
+		return err
 	}
 
 	// 读取并解析配置文件
-	err = parseIniFile(configFile)
+	result, err := parseIniFile(configFile)
 	if err != nil {
-		fmt.Printf("警告：配置文件解析失败，仅使用环境变量和默认值: %v\n", err)
+		logLoadEvent(configFile, nil, err)
+		recordInitError(fmt.Errorf("配置文件 %s 解析失败: %w", configFile, err))
+		return nil
+	}
+
+	if err := checkEmptyConfig(configFile, result); err != nil {
+		currentLogger().Warn("配置文件为空，仅使用环境变量和默认值", "path", configFile, "error", err)
+		recordInitError(err)
+	}
+
+	configMu.Lock()
+	commitLocked(configFile, result)
+	configMu.Unlock()
+
+	logLoadEvent(configFile, result, nil)
+	return nil
+}
+
+// 检查配置文件是否没有任何内容（空文件、只有 BOM/空白或注释），这通常意味着部署出错（如模板渲染失败写出了空文件）
+func checkEmptyConfig(path string, result *parseResult) error {
+	if result.stats.sections == 0 && result.stats.keys == 0 {
+		return fmt.Errorf("配置文件 %s 存在但没有任何节和键", path)
 	}
+	return nil
+}
+
+// 初始化过程中记录的错误（见 InitError）
+var (
+	initErrMu  sync.Mutex
+	initErrors []error
+	// 已记录过无效值错误的配置项，getter 每次调用都会检查，同一配置项只记录一次
+	invalidValueKeys = make(map[[2]string]bool)
+)
+
+// 记录初始化错误
+func recordInitError(err error) {
+	initErrMu.Lock()
+	defer initErrMu.Unlock()
+	initErrors = append(initErrors, err)
+}
+
+// InitError 返回包初始化期间记录的错误（配置文件解析失败、常用配置类型不匹配等），没有错误时返回 nil
+// 初始化阶段不会因这些错误 panic，调用方可在启动时检查并决定是否中止
+func InitError() error {
+	initErrMu.Lock()
+	defer initErrMu.Unlock()
+	return errors.Join(initErrors...)
 }
 
 // 获取配置文件路径（兼容不同运行环境）
@@ -56,134 +239,854 @@ func getConfigFilePath() (string, error) {
 	return "", fmt.Errorf("配置文件未找到（已尝试：%s, %s）", configPath, altConfigPath)
 }
 
-// 解析INI格式配置文件
-func parseIniFile(filePath string) error {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return err
+// 解析配置文件到新的解析结果（按内容识别 INI 或 JSON，见 parseConfigData；含 [meta] extends 继承的基础文件），出错时不影响当前配置
+func parseIniFile(filePath string) (*parseResult, error) {
+	return parseIniFileChain(filePath, nil)
+}
+
+// 解析内存中的配置内容，按内容判断格式（不依赖扩展名）：
+// 去掉 UTF-8 BOM 后第一个非空白字符为 { 时按 JSON 解析，其余（包括以 [节] 开头的内容）一律按 INI 解析
+func parseConfigData(data []byte) (*parseResult, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return parseJSONConfig(data)
 	}
-	defer file.Close()
+	return parseIni(bytes.NewReader(data))
+}
+
+// 单行长度上限（bufio.Scanner 默认只有 64KB，单行的证书、base64 内容很容易超过）
+const maxLineSize = 16 << 20
 
-	scanner := bufio.NewScanner(file)
+// 解析INI格式内容
+func parseIni(r io.Reader) (*parseResult, error) {
+	sections := make(map[string]map[string]string)
+	order := make(map[string][]string)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
 	currentSection := ""
+	active := true // 当前节的条件是否成立
+	skipped := 0
+	lineNum := 0
+	strict := strictParsing.Load()
+
+	// 多行值（heredoc）的解析状态
+	var (
+		inHeredoc    bool
+		heredocKey   string
+		heredocEnd   string
+		heredocStart int
+		heredocLines []string
+	)
+
+	// 保存键值对到当前节（条件不成立的节忽略，节外的键值对计入跳过数）
+	setValue := func(key, value string) {
+		if !active {
+			return
+		}
+		if currentSection == "" {
+			skipped++ // 节外的键值对无法归属，视为无效行
+			return
+		}
+		if _, exists := sections[currentSection][key]; !exists {
+			order[currentSection] = append(order[currentSection], key)
+		}
+		sections[currentSection][key] = value
+	}
 
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+		lineNum++
+		rawLine := scanner.Text()
+		if lineNum == 1 {
+			rawLine = strings.TrimPrefix(rawLine, "\ufeff") // 去掉 UTF-8 BOM
+		}
+
+		// 多行值内部的行原样保留，直到遇到结束标记
+		if inHeredoc {
+			if strings.TrimSpace(rawLine) == heredocEnd {
+				inHeredoc = false
+				setValue(heredocKey, strings.Join(heredocLines, "\n"))
+			} else {
+				heredocLines = append(heredocLines, rawLine)
+			}
+			continue
+		}
+
+		line := strings.TrimSpace(rawLine)
 		// 跳过空行和注释
 		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
 			continue
 		}
 
-		// 匹配节（如 [app]）
-		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
-			currentSection = strings.TrimSpace(line[1 : len(line)-1])
-			if _, exists := config[currentSection]; !exists {
-				config[currentSection] = make(map[string]string)
+		// 匹配节（如 [app]、["a]b"]、[app @if APP_ENV=prod]）
+		if section, condition, ok := parseSectionHeader(line); ok {
+			currentSection = section
+			active = true
+			if condition != "" {
+				holds, err := sectionConditionHolds(condition)
+				if err != nil {
+					currentLogger().Warn("节条件无效，忽略该节的内容", "section", section, "error", err)
+				}
+				// 条件不成立的节整体忽略，成立时合并到同名节
+				active = holds
+			}
+			if _, exists := sections[currentSection]; active && !exists {
+				sections[currentSection] = make(map[string]string)
 			}
 			continue
 		}
+		if strict && looksLikeSectionHeader(line) {
+			return nil, &ParseError{Line: lineNum, Text: line, Msg: "格式错误的节标题"}
+		}
 
 		// 匹配键值对（如 port = 50100）
 		parts := strings.SplitN(line, "=", 2)
 		if len(parts) != 2 {
+			if active {
+				skipped++
+			}
 			continue // 跳过无效行
 		}
 
 		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		// 移除值两侧的引号（兼容带引号的配置）
-		value = strings.Trim(value, "\"'")
+		rawValue := strings.TrimSpace(parts[1])
+
+		// 多行值：cert = <<END 开始，直到单独一行的 END 结束
+		if terminator, ok := heredocTerminator(rawValue); ok {
+			inHeredoc, heredocKey, heredocEnd, heredocStart, heredocLines = true, key, terminator, lineNum, nil
+			continue
+		}
+
+		// 未加引号的值去除首尾空白；加引号的值去掉一对引号后原样保留（如 prompt = "> " 保留末尾空格）
+		setValue(key, unquoteValue(rawValue))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inHeredoc {
+		return nil, &ParseError{Line: heredocStart, Text: heredocKey + " = <<" + heredocEnd, Msg: "多行值缺少结束标记 " + heredocEnd}
+	}
+
+	result := &parseResult{sections: sections, order: order, stats: parseStats{sections: len(sections), skipped: skipped}}
+	for _, sectionMap := range sections {
+		result.stats.keys += len(sectionMap)
+	}
+	return result, nil
+}
 
-		if currentSection != "" {
-			config[currentSection][key] = value
+// 识别多行值的开始标记 <<TERMINATOR（结束标记由字母、数字、下划线组成，可自定义）
+// 多行值中的各行原样保留（包括缩进、以 # 或 ; 开头的行），以换行符连接，不包含结束标记行
+//
+//	cert = <<PEM
+//	-----BEGIN CERTIFICATE-----
+//	MIIB...
+//	-----END CERTIFICATE-----
+//	PEM
+func heredocTerminator(value string) (string, bool) {
+	if !strings.HasPrefix(value, "<<") {
+		return "", false
+	}
+
+	terminator := strings.TrimSpace(value[2:])
+	if terminator == "" {
+		return "", false
+	}
+	for i := 0; i < len(terminator); i++ {
+		if !isEnvNameChar(terminator[i]) {
+			return "", false
 		}
 	}
+	return terminator, true
+}
 
-	return scanner.Err()
+// OrderedKeys 返回指定节中的键，按其在配置文件中首次出现的顺序排列（如有序的中间件列表）
+// 条件节合并进来的新键追加在末尾；JSON 配置无法保留顺序，按键名排序；节不存在时返回 nil
+func OrderedKeys(section string) []string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return append([]string(nil), keyOrder[section]...)
 }
 
-// GetConfig 统一读取配置：优先环境变量 → 配置文件 → 默认值
-// 环境变量名格式：APP_{SECTION}_{KEY}（全大写）
-func GetConfig(section, key string, defaultValue interface{}) interface{} {
-	// 1. 优先读取环境变量
-	envKey := fmt.Sprintf("APP_%s_%s", strings.ToUpper(section), strings.ToUpper(key))
-	if envValue, exists := os.LookupEnv(envKey); exists {
-		return envValue
+// Stats 返回最近一次解析配置文件的统计（不含内嵌默认配置）：节数、键数、跳过的无效行数（空行和注释不计入）
+// 键数接近 0 而跳过行数较多时，通常说明文件格式错误
+func Stats() (sections, keys, skipped int) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return fileResult.stats.sections, fileResult.stats.keys, fileResult.stats.skipped
+}
+
+// 严格解析模式（见 SetStrictParsing）
+var strictParsing atomic.Bool
+
+// ParseError 严格解析模式下的语法错误，包含出错的行号和原始内容
+type ParseError struct {
+	Line int
+	Text string
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("配置解析错误（第 %d 行）：%s: %q", e.Line, e.Msg, e.Text)
+}
+
+// SetStrictParsing 开启/关闭严格解析模式（默认关闭，保持宽松解析）
+// 严格模式下，看起来想写节标题但格式错误的行（如缺少右括号的 [app，或 app] 这类只有右括号的行）
+// 会使解析返回 *ParseError，避免被截断的节标题把后续键静默归入错误的节
+func SetStrictParsing(strict bool) {
+	strictParsing.Store(strict)
+}
+
+// 判断未能解析为节标题的行是否像是写错的节标题
+func looksLikeSectionHeader(line string) bool {
+	if strings.HasPrefix(line, "[") {
+		return true
 	}
+	return strings.HasSuffix(line, "]") && !strings.Contains(line, "=")
+}
 
-	// 2. 读取配置文件
-	sectionMap, sectionExists := config[section]
-	if sectionExists {
-		if value, keyExists := sectionMap[key]; keyExists {
-			return value
+// 去除值两侧成对的引号（"..." 或 '...'），引号内的内容（包括首尾空白）原样保留
+// 内部还含有同种引号时（如 "a", "b" 这类带引号的列表）视为未整体加引号，原样返回
+func unquoteValue(value string) string {
+	if len(value) >= 2 {
+		first := value[0]
+		inner := value[1 : len(value)-1]
+		if (first == '"' || first == '\'') && value[len(value)-1] == first && strings.IndexByte(inner, first) < 0 {
+			return inner
 		}
 	}
+	return value
+}
+
+// 解析节标题：支持 [name] 与 ["name"] 两种写法，以及可选的条件后缀 [name @if KEY=VALUE]
+// 引号内的方括号、空格等字符按字面处理（如 ["a]b"] → a]b），可用 \" 和 \\ 转义
+// 返回节名、条件表达式（无条件时为空）以及是否为合法的节标题
+func parseSectionHeader(line string) (string, string, bool) {
+	if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+		return "", "", false
+	}
+
+	inner := strings.TrimSpace(line[1 : len(line)-1])
+	if !strings.HasPrefix(inner, "\"") {
+		name, condition := splitSectionCondition(inner)
+		return name, condition, true
+	}
+
+	var name strings.Builder
+	for i := 1; i < len(inner); i++ {
+		switch c := inner[i]; c {
+		case '\\':
+			if i+1 < len(inner) {
+				i++
+				name.WriteByte(inner[i])
+			}
+		case '"':
+			// 闭合引号之后只允许条件后缀
+			rest, condition := splitSectionCondition(inner[i+1:])
+			if rest != "" {
+				return "", "", false
+			}
+			return name.String(), condition, true
+		default:
+			name.WriteByte(c)
+		}
+	}
+	return "", "", false // 引号未闭合
+}
+
+// 拆分节标题中的条件后缀：app @if APP_ENV=prod → ("app", "APP_ENV=prod")
+func splitSectionCondition(s string) (string, string) {
+	idx := strings.LastIndex(s, "@if ")
+	if idx < 0 {
+		return strings.TrimSpace(s), ""
+	}
+	return strings.TrimSpace(s[:idx]), strings.TrimSpace(s[idx+len("@if "):])
+}
+
+// 判断节条件是否成立：目前支持环境变量相等比较 KEY=VALUE
+func sectionConditionHolds(condition string) (bool, error) {
+	name, want, ok := strings.Cut(condition, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return false, fmt.Errorf("无法识别的节条件 %q（应为 KEY=VALUE）", condition)
+	}
+	return os.Getenv(name) == strings.TrimSpace(want), nil
+}
 
+// GetConfig 统一读取配置：命令行参数（见 RegisterFlags）→ 环境变量 → 配置来源（默认为配置文件）→ 默认值
+// 环境变量名格式：APP_{SECTION}_{KEY}（全大写，与大小写敏感模式无关），也可通过 BindEnv 指定
+// 全大写的变量不存在时，再查找保留原始大小写的 APP_{section}_{key}（兼容不做大写转换的平台）
+// 包含 {{ }} 的值按 Go text/template 渲染（见 renderValue），默认值不参与渲染
+// 配置文件中的 $NAME / ${NAME} 替换为对应环境变量的值，${NAME:-default} 在变量未定义或为空时使用默认值（见 expandEnvRefs）
+func GetConfig(section, key string, defaultValue interface{}) interface{} {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	if value, exists := lookupLocked(section, key); exists {
+		return value
+	}
 	// 3. 返回默认值
 	return defaultValue
 }
 
+// GetMany 批量读取同一节下的多个键，整个过程只加一次读锁
+// 每个键按与 GetConfig 相同的优先级解析（环境变量逐键生效）
+// 环境变量和配置文件中都不存在的键不会出现在返回结果中
+func GetMany(section string, keys []string) map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	result := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if value, exists := lookupLocked(section, key); exists {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// GetStringAny 按顺序尝试多个键（如迁移期间先查旧键再查新键），返回第一个存在的值，都不存在时返回默认值
+// 每个候选键都按与 GetConfig 相同的优先级解析
+func GetStringAny(section string, keys []string, defaultValue string) string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	for _, key := range keys {
+		if value, exists := lookupLocked(section, key); exists {
+			return value
+		}
+	}
+	return defaultValue
+}
+
+// GetStringOrKey 读取 key，不存在时改用同一节的 fallbackKey，都不存在时返回默认值，
+// 用于"未设置时取另一个键"的派生默认值，如 display_name 默认等于 username；两次查找都按 GetConfig 的优先级解析
+func GetStringOrKey(section, key, fallbackKey, defaultValue string) string {
+	return GetStringAny(section, []string{key, fallbackKey}, defaultValue)
+}
+
+// GetPath 以点分路径读取配置，按最后一个点拆分节名与键名，其余规则与 GetConfig 相同
+// 例如 GetPath("server.tls.cert_file", "") 等价于 GetConfig("server.tls", "cert_file", "")
+// 因此键名本身不能包含点；路径中没有点时直接返回默认值
+func GetPath(path string, defaultValue interface{}) interface{} {
+	idx := strings.LastIndex(path, ".")
+	if idx < 0 {
+		return defaultValue
+	}
+	return GetConfig(path[:idx], path[idx+1:], defaultValue)
+}
+
+// 按优先级解析配置值，并处理已废弃键的回退（调用方需持有 configMu 读锁）
+func lookupLocked(section, key string) (string, bool) {
+	if missingConfigWarning.Load() != nil {
+		flushMissingConfigWarning()
+	}
+	value, source, exists := lookupRawLocked(section, key)
+	if !exists {
+		return "", false
+	}
+	return processSourceValue(value, source), true
+}
+
+// 按优先级查找原始值及其来源，新键不存在时回退到已废弃的旧键，不做任何值处理（调用方需持有 configMu 读锁）
+func lookupRawLocked(section, key string) (string, valueSource, bool) {
+	if value, source, exists := resolveRawLocked(section, key); exists {
+		if newKey, deprecated := deprecatedKeys[section][key]; deprecated {
+			warnDeprecatedKey(section, key, newKey)
+		}
+		return value, source, true
+	}
+
+	// 新键不存在时回退到已废弃的旧键（见 DeprecateKey）
+	if oldKey, renamed := renamedKeys[section][key]; renamed {
+		if value, source, exists := resolveRawLocked(section, oldKey); exists {
+			warnDeprecatedKey(section, oldKey, key)
+			return value, source, true
+		}
+	}
+
+	// 跨节别名的旧位置（见 SetAliases）
+	if oldLoc, aliased := keyAliases[[2]string{section, key}]; aliased {
+		if value, source, exists := resolveRawLocked(oldLoc[0], oldLoc[1]); exists {
+			warnAliasedKey(oldLoc, [2]string{section, key})
+			return value, source, true
+		}
+	}
+	return "", 0, false
+}
+
+// 配置值来源
+type valueSource int
+
+const (
+	sourceFlag valueSource = iota
+	sourceEnv
+	sourceProvider
+	sourceOverride
+	sourceLiteral // 配置文件中 @file 引用的文件内容（属于配置来源层，但值原样返回）
+)
+
+// 按来源处理原始值：配置来源中的值渲染模板或展开环境变量引用，@file 引用的文件内容原样返回，
+// 其余来源只渲染模板（调用方需持有 configMu 读锁）
+func processSourceValue(value string, source valueSource) string {
+	switch source {
+	case sourceProvider:
+		return processValue(value)
+	case sourceLiteral:
+		return value
+	}
+	return renderValue(value)
+}
+
+// 按 覆盖层 → 命令行参数 → 环境变量 → 配置来源 的顺序查找单个键的原始值及其来源，不做任何值处理（调用方需持有 configMu 读锁）
+func resolveRawLocked(section, key string) (string, valueSource, bool) {
+	// 运行时覆盖层，栈顶优先（见 PushOverrides）
+	if value, exists := lookupOverridesLocked(section, key); exists {
+		return value, sourceOverride, true
+	}
+
+	// 仅从环境变量读取的节（见 MarkSectionEnvOnly）
+	if envOnlySections[section] {
+		if envValue, exists := lookupEnvLocked(section, key); exists {
+			return envValue, sourceEnv, true
+		}
+		return "", 0, false
+	}
+
+	// 0. 显式设置的命令行参数
+	if flagValue, exists := lookupFlagLocked(section, key); exists {
+		return flagValue, sourceFlag, true
+	}
+
+	// 1. 读取环境变量（BindEnv 绑定的变量优先于 APP_{SECTION}_{KEY}）
+	if envValue, exists := lookupEnvLocked(section, key); exists {
+		return envValue, sourceEnv, true
+	}
+
+	// 2. 依次查询配置来源（默认仅配置文件，见 RegisterProvider）
+	if value, source, exists := lookupProvidersSourceLocked(section, key); exists {
+		return value, source, true
+	}
+
+	// 3. 节的回退链（见 SetSectionFallbacks）
+	if len(sectionFallbacks[section]) > 0 {
+		for _, fallback := range sectionChainLocked(section)[1:] {
+			if value, source, exists := lookupProvidersSourceLocked(fallback, key); exists {
+				return value, source, true
+			}
+		}
+	}
+
+	// 4. 文件中声明的默认节（见 SetDefaultSection）
+	if defaultSection != "" && section != defaultSection {
+		if value, source, exists := lookupProvidersSourceLocked(defaultSection, key); exists {
+			return value, source, true
+		}
+	}
+	return "", 0, false
+}
+
+// HasKey 判断配置项是否存在（只关心是否出现，不关心取值，如 [flags] 节中值为空的开关）
+// 命令行参数、环境变量（APP_{SECTION}_{KEY} 已设置，即使为空）或配置来源中任一存在即返回 true
+func HasKey(section, key string) bool {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	_, _, exists := resolveRawLocked(section, key)
+	return exists
+}
+
+// FirstPresentSection 按顺序返回候选节中第一个存在的节（如在 [backend.redis] 与 [backend.memory] 中确定启用了哪个后端）
+// 节存在指配置文件或内嵌默认配置中出现过该节（即使节内没有键），或运行时覆盖层中包含该节；
+// 约定的 APP_ 环境变量无法还原节名，不会让节变为存在（本包没有把环境变量整体合并为节的功能）
+// 遵循 SetCaseSensitive 的大小写规则；都不存在时返回 "", false
+func FirstPresentSection(candidates []string) (string, bool) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	for _, section := range candidates {
+		if sectionExistsLocked(section) {
+			return section, true
+		}
+	}
+	return "", false
+}
+
+// MustSection 返回指定节全部键的值（按 GetConfig 的优先级解析，返回副本），供缺少整个配置块就无法工作的功能在初始化时使用
+// 节不存在（规则同 FirstPresentSection）时 panic，错误信息包含节名和当前配置文件路径
+func MustSection(name string) map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	if !sectionExistsLocked(name) {
+		path := configFilePath
+		if path == "" {
+			path = "(未加载配置文件)"
+		}
+		panic(fmt.Sprintf("config: 缺少必需的配置节 [%s]（配置文件：%s）", name, path))
+	}
+
+	keys := make(map[string]bool, len(config[name]))
+	for key := range config[name] {
+		keys[key] = true
+	}
+	for _, layer := range overrideStack {
+		for key := range layer[name] {
+			keys[key] = true
+		}
+	}
+
+	result := make(map[string]string, len(keys))
+	for key := range keys {
+		if value, exists := lookupLocked(name, key); exists {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// 判断节是否存在（调用方需持有 configMu 读锁）
+func sectionExistsLocked(section string) bool {
+	if _, exists := config[section]; exists {
+		return true
+	}
+	for _, layer := range overrideStack {
+		if _, exists := layer[section]; exists {
+			return true
+		}
+	}
+	if caseSensitiveFor(section) {
+		return false
+	}
+	for name := range config {
+		if strings.EqualFold(name, section) {
+			return true
+		}
+	}
+	return false
+}
+
+// 环境变量名缓存：(section, key, 是否大写) → 环境变量名，避免热路径上重复拼接和大写转换
+type envKeyID struct {
+	section string
+	key     string
+	upper   bool
+}
+
+var (
+	envKeyMu    sync.RWMutex
+	envKeyCache = make(map[envKeyID]string)
+)
+
+// 环境变量名缓存的容量上限：节名/键名可能来自请求参数（如 GetMessage 的语言），
+// 缓存满后新的组合照常计算但不再缓存，避免由外部输入撑大内存
+const maxEnvKeyCache = 4096
+
+// 获取配置项对应的环境变量名 APP_{SECTION}_{KEY}（首次计算后缓存，命中缓存时不产生内存分配）
+func envKeyFor(section, key string) string {
+	return cachedEnvKey(envKeyID{section: section, key: key, upper: true})
+}
+
+// 获取保留原始大小写的环境变量名 APP_{section}_{key}
+func exactEnvKeyFor(section, key string) string {
+	return cachedEnvKey(envKeyID{section: section, key: key})
+}
+
+func cachedEnvKey(id envKeyID) string {
+	envKeyMu.RLock()
+	envKey, cached := envKeyCache[id]
+	envKeyMu.RUnlock()
+	if cached {
+		return envKey
+	}
+
+	section, key := id.section, id.key
+	if id.upper {
+		section, key = strings.ToUpper(section), strings.ToUpper(key)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len("APP__") + len(section) + len(key))
+	sb.WriteString("APP_")
+	sb.WriteString(section)
+	sb.WriteByte('_')
+	sb.WriteString(key)
+	envKey = sb.String()
+
+	envKeyMu.Lock()
+	if len(envKeyCache) < maxEnvKeyCache {
+		envKeyCache[id] = envKey
+	}
+	envKeyMu.Unlock()
+	return envKey
+}
+
+// SetCaseSensitive 设置配置文件中节名/键名的匹配方式
+// 默认 true：仅精确匹配（适合 base64 令牌等本身区分大小写的键）
+// 设为 false：精确匹配失败后再按忽略大小写匹配（精确匹配始终优先）
+// 注意：环境变量覆盖始终查找全大写的 APP_{SECTION}_{KEY}，两种模式下都不区分大小写
+func SetCaseSensitive(sensitive bool) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	caseSensitive = sensitive
+}
+
+// 按节设置的大小写模式：小写节名 → 是否大小写敏感（读写均需持有 configMu）
+var sectionCaseSensitive = make(map[string]bool)
+
+// SetSectionCaseSensitive 单独设置某个节的匹配方式，未设置的节沿用 SetCaseSensitive 的全局设置
+// 例如全局忽略大小写以方便书写，同时让存放令牌的 [secrets] 保持精确匹配：
+//
+//	config.SetCaseSensitive(false)
+//	config.SetSectionCaseSensitive("secrets", true)
+//
+// 节名按忽略大小写匹配该设置（[Secrets] 与 [secrets] 共用同一设置）
+func SetSectionCaseSensitive(section string, sensitive bool) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	sectionCaseSensitive[strings.ToLower(section)] = sensitive
+}
+
+// 获取节实际使用的大小写模式（调用方需持有 configMu）
+func caseSensitiveFor(section string) bool {
+	if len(sectionCaseSensitive) > 0 {
+		if sensitive, exists := sectionCaseSensitive[strings.ToLower(section)]; exists {
+			return sensitive
+		}
+	}
+	return caseSensitive
+}
+
+// 在配置文件中查找键值（按节的大小写模式，调用方需持有 configMu）
+func lookupFile(section, key string) (string, bool) {
+	value, _, exists := lookupFileEntry(section, key)
+	return value, exists
+}
+
+// 在配置文件中查找键值，第二个返回值表示值是否来自 @file 引用（调用方需持有 configMu）
+func lookupFileEntry(section, key string) (string, bool, bool) {
+	if sectionMap, exists := config[section]; exists {
+		if value, exists := sectionMap[key]; exists {
+			return value, literalKeys[[2]string{section, key}], true
+		}
+	}
+	if caseSensitiveFor(section) {
+		return "", false, false
+	}
+
+	for name, sectionMap := range config {
+		if !strings.EqualFold(name, section) {
+			continue
+		}
+		for k, value := range sectionMap {
+			if strings.EqualFold(k, key) {
+				return value, literalKeys[[2]string{name, k}], true
+			}
+		}
+	}
+	return "", false, false
+}
+
 // -------------------------- 封装常用配置（直接导入使用） --------------------------
 
+// 以下变量在 init 中加载配置文件之后赋值（包级变量的初始化早于 init，直接初始化会读不到配置文件）
+
 // 字符串类型配置
 var (
-	APP_NAME              = GetConfig("app", "name", "flask-echo").(string)
-	APP_HOST              = GetConfig("server", "host", "0.0.0.0").(string)
-	APP_LOG_PATH          = GetConfig("server", "log_path", "/app/log").(string)
-	CONTAINER_LOG_PATH    = GetConfig("server", "container_log_path", "/var/log").(string)
-	DOCKER_IMAGE_NAME     = GetConfig("docker", "image_name", "flask-echo").(string)
-	DOCKER_CONTAINER_NAME = GetConfig("docker", "container_name", "flask-echo-container").(string)
+	APP_NAME              string
+	APP_HOST              string
+	APP_LOG_PATH          string
+	CONTAINER_LOG_PATH    string
+	DOCKER_IMAGE_NAME     string
+	DOCKER_CONTAINER_NAME string
 )
 
 // 数值/布尔类型配置（需要类型转换）
 var (
-	APP_PORT  = getIntConfig("app", "port", 50100)
-	APP_DEBUG = getBoolConfig("app", "debug", false)
+	APP_PORT  int
+	APP_DEBUG bool
 )
 
-// 辅助函数：获取整数类型配置
-func getIntConfig(section, key string, defaultValue int) int {
-	value := GetConfig(section, key, fmt.Sprintf("%d", defaultValue))
+// 为常用配置变量赋值
+func loadCachedVars() {
+	APP_NAME = getStringConfig("app", "name", "flask-echo")
+	APP_HOST = getStringConfig(keySection("server", "app", "host"), "host", "0.0.0.0")
+	APP_LOG_PATH = getStringConfig("server", "log_path", "/app/log")
+	CONTAINER_LOG_PATH = getStringConfig("server", "container_log_path", "/var/log")
+	DOCKER_IMAGE_NAME = getStringConfig("docker", "image_name", "flask-echo")
+	DOCKER_CONTAINER_NAME = getStringConfig("docker", "container_name", "flask-echo-container")
+
+	APP_PORT = getIntConfig(keySection("app", "server", "port"), "port", 50100)
+	APP_DEBUG = getBoolConfig("app", "debug", false)
+}
+
+// 确定从哪个节读取键：host 归 [server]、port 归 [app]（与内置 config.ini 一致）
+// 规定的节中不存在、而另一个节中存在时（如误写为 [app] host）回退到另一个节，并记录迁移警告（每个配置项只记录一次）
+func keySection(owner, alternate, key string) string {
+	if HasKey(owner, key) || !HasKey(alternate, key) {
+		return owner
+	}
+	warnOnce(alternate, key, func() {
+		currentLogger().Warn("配置项放错了节，请迁移", "key", key, "section", alternate, "want", owner)
+	})
+	return alternate
+}
+
+// 辅助函数：获取字符串类型配置，GetConfig 返回非字符串时记录到 InitError 并返回默认值，不会 panic
+func getStringConfig(section, key, defaultValue string) string {
+	value := GetConfig(section, key, defaultValue)
 	strVal, ok := value.(string)
 	if !ok {
+		recordInitError(fmt.Errorf("配置项 [%s] %s 类型不匹配：期望 string，实际为 %T", section, key, value))
+		return defaultValue
+	}
+	return strVal
+}
+
+// 辅助函数：读取配置值（不含默认值），第二个返回值表示环境变量或配置来源中是否存在
+func lookup(section, key string) (string, bool) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return lookupLocked(section, key)
+}
+
+// 辅助函数：打印配置值无效、回退默认值的警告；严格类型模式下同时记录到 InitError（见 SetStrictTypes）
+// 同一配置项只记录一次错误，避免 getter 被反复调用时 InitError 无限增长
+func warnInvalidValue(section, key, value string, err error) {
+	fmt.Printf("警告：配置项 [%s] %s 的值 %q 无效，使用默认值: %v\n", section, key, value, err)
+	if strictTypes.Load() {
+		recordInvalidValue(section, key, fmt.Errorf("配置项 [%s] %s 的值 %q 无效: %w", section, key, value, err))
+	}
+}
+
+// 记录配置项的无效值错误，已记录过的配置项不再重复记录
+func recordInvalidValue(section, key string, err error) {
+	initErrMu.Lock()
+	defer initErrMu.Unlock()
+	id := [2]string{section, key}
+	if invalidValueKeys[id] {
+		return
+	}
+	invalidValueKeys[id] = true
+	initErrors = append(initErrors, err)
+}
+
+// 严格类型模式（见 SetStrictTypes）
+var strictTypes atomic.Bool
+
+// SetStrictTypes 开启/关闭严格类型模式（默认关闭，保持原有的宽松行为）
+// 宽松模式下 getIntConfig、getFloatConfig、getBoolConfig 遇到无法解析的值（如 port = abc）静默返回默认值；
+// 严格模式下这些以及其他类型化 getter 遇到无效值时除了打印警告外，还会把错误记录到 InitError，
+// 便于启动时检查 InitError 并快速失败，而不是带着错误的端口运行（getter 本身仍返回默认值，不会 panic）
+func SetStrictTypes(strict bool) {
+	strictTypes.Store(strict)
+}
+
+// 辅助函数：宽松模式下静默回退默认值的 getter 使用，严格类型模式下按 warnInvalidValue 报告
+func reportTypeMismatch(section, key, value string, err error) {
+	if strictTypes.Load() {
+		warnInvalidValue(section, key, value, err)
+	}
+}
+
+// 辅助函数：获取整数类型配置（默认值保持原生类型，只解析环境变量/配置文件中的值）
+func getIntConfig(section, key string, defaultValue int) int {
+	strVal, exists := lookup(section, key)
+	if !exists {
 		return defaultValue
 	}
 
-	intVal, err := strconv.Atoi(strVal)
+	intVal, err := strconv.Atoi(stripThousandsSeparators(strings.TrimSpace(strVal)))
 	if err != nil {
+		reportTypeMismatch(section, key, strVal, fmt.Errorf("应为整数"))
 		return defaultValue
 	}
 	return intVal
 }
 
-// 辅助函数：获取布尔类型配置（兼容 true/false、1/0、yes/no）
+// 辅助函数：获取浮点数类型配置（默认值保持原生类型），NaN、Inf 等非有限值视为无效，返回默认值
+func getFloatConfig(section, key string, defaultValue float64) float64 {
+	strVal, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	floatVal, err := parseFloatValue(strVal)
+	if err != nil {
+		reportTypeMismatch(section, key, strVal, err)
+		return defaultValue
+	}
+	return floatVal
+}
+
+// 辅助函数：解析有限的浮点数（去除首尾空白，拒绝 NaN、Inf）
+func parseFloatValue(s string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("不是有限的数值")
+	}
+	return f, nil
+}
+
+// 千位分隔符格式：逗号必须严格三位一组（10,000），下划线只要求位于数字之间（10_000）
+var (
+	commaGroupedInt      = regexp.MustCompile(`^[+-]?\d{1,3}(,\d{3})+$`)
+	underscoreGroupedInt = regexp.MustCompile(`^[+-]?\d+(_\d+)+$`)
+)
+
+// 辅助函数：去除整数中的千位分隔符（"10,000"、"10_000" → "10000"）
+// 不符合分组规则的值（如逗号列表 "1,2,3"）原样返回，交由后续解析判定为无效
+func stripThousandsSeparators(s string) string {
+	switch {
+	case commaGroupedInt.MatchString(s):
+		return strings.ReplaceAll(s, ",", "")
+	case underscoreGroupedInt.MatchString(s):
+		return strings.ReplaceAll(s, "_", "")
+	default:
+		return s
+	}
+}
+
+// 辅助函数：获取布尔类型配置（兼容 true/false、1/0、yes/no，默认值保持原生类型）
 func getBoolConfig(section, key string, defaultValue bool) bool {
-	value := GetConfig(section, key, fmt.Sprintf("%t", defaultValue))
-	strVal, ok := value.(string)
-	if !ok {
+	strVal, exists := lookup(section, key)
+	if !exists {
 		return defaultValue
 	}
 
-	strVal = strings.ToLower(strings.TrimSpace(strVal))
-	switch strVal {
+	if boolVal, ok := parseBoolValue(strVal); ok {
+		return boolVal
+	}
+	reportTypeMismatch(section, key, strVal, fmt.Errorf("无法识别的布尔值"))
+	return defaultValue
+}
+
+// 辅助函数：按 true/false、1/0、yes/no、on/off 规则解析布尔值（忽略大小写），第二个返回值表示是否可识别
+func parseBoolValue(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "true", "1", "yes", "on":
-		return true
+		return true, true
 	case "false", "0", "no", "off":
-		return false
+		return false, true
 	default:
-		return defaultValue
+		return false, false
 	}
 }
 
-// 辅助函数：格式化输出所有配置（调试用）
+// 辅助函数：格式化输出所有配置（调试用，按脱敏规则处理，见 SetRedactor）
 func PrintAllConfigs() {
+	show := func(section, key string, value interface{}) string {
+		return redactValue(section, key, fmt.Sprint(value))
+	}
+
 	fmt.Println("=== 当前配置 ===")
-	fmt.Printf("APP_NAME: %s\n", APP_NAME)
-	fmt.Printf("APP_PORT: %d\n", APP_PORT)
-	fmt.Printf("APP_HOST: %s\n", APP_HOST)
-	fmt.Printf("APP_DEBUG: %t\n", APP_DEBUG)
-	fmt.Printf("APP_LOG_PATH: %s\n", APP_LOG_PATH)
-	fmt.Printf("CONTAINER_LOG_PATH: %s\n", CONTAINER_LOG_PATH)
-	fmt.Printf("DOCKER_IMAGE_NAME: %s\n", DOCKER_IMAGE_NAME)
-	fmt.Printf("DOCKER_CONTAINER_NAME: %s\n", DOCKER_CONTAINER_NAME)
+	fmt.Printf("APP_NAME: %s\n", show("app", "name", APP_NAME))
+	fmt.Printf("APP_PORT: %s\n", show("app", "port", APP_PORT))
+	fmt.Printf("APP_HOST: %s\n", show("server", "host", APP_HOST))
+	fmt.Printf("APP_DEBUG: %s\n", show("app", "debug", APP_DEBUG))
+	fmt.Printf("APP_LOG_PATH: %s\n", show("server", "log_path", APP_LOG_PATH))
+	fmt.Printf("CONTAINER_LOG_PATH: %s\n", show("server", "container_log_path", CONTAINER_LOG_PATH))
+	fmt.Printf("DOCKER_IMAGE_NAME: %s\n", show("docker", "image_name", DOCKER_IMAGE_NAME))
+	fmt.Printf("DOCKER_CONTAINER_NAME: %s\n", show("docker", "container_name", DOCKER_CONTAINER_NAME))
 }
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEnvKeyCacheBounded(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		GetMessage("lang"+strconv.Itoa(i), "welcome", "")
+	}
+
+	envKeyMu.RLock()
+	size := len(envKeyCache)
+	envKeyMu.RUnlock()
+	if size > maxEnvKeyCache {
+		t.Errorf("环境变量名缓存有 %d 项，超过上限 %d", size, maxEnvKeyCache)
+	}
+
+	// 缓存满后仍返回正确的变量名
+	if got := envKeyFor("messages.lang9999", "welcome"); got != "APP_MESSAGES.LANG9999_WELCOME" {
+		t.Errorf("envKeyFor = %q", got)
+	}
+}
+
+func BenchmarkGetConfig(b *testing.B) {
+	configMu.Lock()
+	commitLocked("", &parseResult{
+		sections: map[string]map[string]string{"app": {"name": "did-new", "port": "50100"}},
+		order:    map[string][]string{"app": {"name", "port"}},
+	})
+	configMu.Unlock()
+	b.Cleanup(resetTestConfig)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GetConfig("app", "name", "")
+	}
+}
+
+func BenchmarkEnvKeyFor(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		envKeyFor("app", "name")
+	}
+}
+
+// 解析 INI 内容，失败时测试失败
+func mustParseIni(t *testing.T, content string) *parseResult {
+	t.Helper()
+	result, err := parseIni(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("parseIni: %v", err)
+	}
+	return result
+}
+
+func TestParseSectionHeaderQuoted(t *testing.T) {
+	tests := []struct {
+		line, name, condition string
+		ok                    bool
+	}{
+		{`[app]`, "app", "", true},
+		{`["a]b"]`, "a]b", "", true},
+		{`["[nested]"]`, "[nested]", "", true},
+		{`["my section"]`, "my section", "", true},
+		{`[  "  padded  "  ]`, "  padded  ", "", true},
+		{`["say \"hi\""]`, `say "hi"`, "", true},
+		{`["back\\slash"]`, `back\slash`, "", true},
+		{`["a b" @if APP_ENV=prod]`, "a b", "APP_ENV=prod", true},
+		{`["unterminated]`, "", "", false},
+		{`["a" trailing]`, "", "", false},
+	}
+	for _, tt := range tests {
+		name, condition, ok := parseSectionHeader(tt.line)
+		if name != tt.name || condition != tt.condition || ok != tt.ok {
+			t.Errorf("parseSectionHeader(%s) = (%q, %q, %v), want (%q, %q, %v)",
+				tt.line, name, condition, ok, tt.name, tt.condition, tt.ok)
+		}
+	}
+}
+
+func TestParseIniQuotedSectionNames(t *testing.T) {
+	result := mustParseIni(t, "[\"a]b\"]\nkey = 1\n[\"with space\"]\nkey = 2\n")
+	if got := result.sections["a]b"]["key"]; got != "1" {
+		t.Errorf(`["a]b"] key = %q, want 1`, got)
+	}
+	if got := result.sections["with space"]["key"]; got != "2" {
+		t.Errorf(`["with space"] key = %q, want 2`, got)
+	}
+}
+
+func TestQuotedValueKeepsWhitespace(t *testing.T) {
+	loadTestConfig(t, "[cli]\nprompt = \"> \"\nname =   bob  \nmixed = \"a\", \"b\"\nsingle = ' x '\n")
+
+	tests := []struct{ key, want string }{
+		{"prompt", "> "},
+		{"name", "bob"},
+		{"mixed", `"a", "b"`}, // 内部还有同种引号，不视为整体加引号
+		{"single", " x "},
+	}
+	for _, tt := range tests {
+		if got := mustLookup(t, "cli", tt.key); got != tt.want {
+			t.Errorf("[cli] %s = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+// 多行 PEM 证书，包含 = 和以 # 开头的行，验证块内的内容不按键值或注释解析
+const testPEM = `-----BEGIN CERTIFICATE-----
+MIIBszCCAVmgAwIBAgIUY29uZmlnLXRlc3Q=
+# not a comment
+  indented = not a key
+
+-----END CERTIFICATE-----`
+
+func TestParseIniHeredocPEM(t *testing.T) {
+	result := mustParseIni(t, "[tls]\ncert = <<PEM\n"+testPEM+"\n  PEM\nkey_file = /etc/tls/key.pem\n")
+
+	if got := result.sections["tls"]["cert"]; got != testPEM {
+		t.Errorf("cert = %q, want %q", got, testPEM)
+	}
+	if got := result.sections["tls"]["key_file"]; got != "/etc/tls/key.pem" {
+		t.Errorf("结束标记之后的键 key_file = %q", got)
+	}
+	if _, exists := result.sections["tls"]["indented"]; exists {
+		t.Error("多行值内部的行不应解析为键")
+	}
+}
+
+func TestParseIniHeredocUnterminated(t *testing.T) {
+	_, err := parseIni(strings.NewReader("[tls]\nname = x\ncert = <<PEM\n" + testPEM + "\n"))
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("未闭合的多行值应返回 *ParseError，实际 %v", err)
+	}
+	if parseErr.Line != 3 {
+		t.Errorf("ParseError.Line = %d, want 3（多行值开始的行）", parseErr.Line)
+	}
+	if !strings.Contains(parseErr.Msg, "PEM") {
+		t.Errorf("错误信息应指出缺少的结束标记: %v", parseErr)
+	}
+}
+
+func TestParseIniLongLine(t *testing.T) {
+	blob := strings.Repeat("QUJD", 50*1024) // 200KB，超过 bufio.Scanner 默认的 64KB
+	path := writeTestFile(t, "config.ini", "[tls]\nblob = "+blob+"\nafter = ok\n")
+
+	result, err := parseIniFile(path)
+	if err != nil {
+		t.Fatalf("parseIniFile: %v", err)
+	}
+	if got := result.sections["tls"]["blob"]; got != blob {
+		t.Errorf("blob 长度为 %d，want %d", len(got), len(blob))
+	}
+	if got := result.sections["tls"]["after"]; got != "ok" {
+		t.Errorf("长行之后的键 after = %q", got)
+	}
+}
+
+func TestSectionCaseSensitivityMixed(t *testing.T) {
+	loadTestConfig(t, "[App]\nName = did-new\n[Secrets]\nToken = AbC\n")
+	SetCaseSensitive(false)
+	SetSectionCaseSensitive("secrets", true)
+	t.Cleanup(func() {
+		configMu.Lock()
+		caseSensitive = true
+		sectionCaseSensitive = make(map[string]bool)
+		configMu.Unlock()
+	})
+
+	if got, exists := lookup("app", "name"); !exists || got != "did-new" {
+		t.Errorf("忽略大小写的节 [app] name = %q, %v", got, exists)
+	}
+	if _, exists := lookup("secrets", "token"); exists {
+		t.Error("大小写敏感的节不应按忽略大小写匹配")
+	}
+	if got := mustLookup(t, "Secrets", "Token"); got != "AbC" {
+		t.Errorf("[Secrets] Token = %q, want AbC", got)
+	}
+
+	// 反过来：全局大小写敏感，单独放宽某个节
+	SetCaseSensitive(true)
+	SetSectionCaseSensitive("SECRETS", false)
+	if got, exists := lookup("secrets", "token"); !exists || got != "AbC" {
+		t.Errorf("放宽后的节 [secrets] token = %q, %v", got, exists)
+	}
+	if _, exists := lookup("app", "name"); exists {
+		t.Error("全局大小写敏感时 [app] 不应匹配 [App]")
+	}
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// 字节大小单位（按 1024 进制，KB 与 KiB 等价）
+var byteSizeUnits = []struct {
+	suffixes []string
+	name     string
+	size     int64
+}{
+	{[]string{"tb", "tib", "t"}, "TB", 1 << 40},
+	{[]string{"gb", "gib", "g"}, "GB", 1 << 30},
+	{[]string{"mb", "mib", "m"}, "MB", 1 << 20},
+	{[]string{"kb", "kib", "k"}, "KB", 1 << 10},
+	{[]string{"b", ""}, "B", 1},
+}
+
+// 辅助函数：获取字节大小配置（如 max_upload = 10MB），返回字节数
+// 支持 B、KB、MB、GB、TB 后缀（忽略大小写，按 1024 进制，也接受 KiB/MiB 等写法），数字可带小数；格式错误时返回默认值
+func getByteSizeConfig(section, key string, defaultValue int64) int64 {
+	bytes, _ := getSizeConfig(section, key, defaultValue)
+	return bytes
+}
+
+// 辅助函数：获取字节大小配置，同时返回规范化的可读形式（如 "10 MB"），便于设置界面回显
+// 解析规则同 getByteSizeConfig；缺失或格式错误时返回默认字节数及其可读形式
+func getSizeConfig(section, key string, defaultValue int64) (bytes int64, human string) {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue, formatByteSize(defaultValue)
+	}
+
+	bytes, err := parseByteSize(value)
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue, formatByteSize(defaultValue)
+	}
+	return bytes, formatByteSize(bytes)
+}
+
+// 解析带单位的字节大小
+func parseByteSize(value string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	numEnd := len(s)
+	for numEnd > 0 && (s[numEnd-1] < '0' || s[numEnd-1] > '9') && s[numEnd-1] != '.' {
+		numEnd--
+	}
+	number, suffix := strings.TrimSpace(s[:numEnd]), strings.TrimSpace(s[numEnd:])
+
+	for _, unit := range byteSizeUnits {
+		if !containsString(unit.suffixes, suffix) {
+			continue
+		}
+		f, err := strconv.ParseFloat(number, 64)
+		if err != nil || f < 0 {
+			return 0, fmt.Errorf("无法解析的字节大小 %q", value)
+		}
+		total := f * float64(unit.size)
+		if total >= math.MaxInt64 {
+			return 0, fmt.Errorf("字节大小溢出")
+		}
+		return int64(total), nil
+	}
+	return 0, fmt.Errorf("未知的字节大小单位 %q", suffix)
+}
+
+// 将字节数格式化为可读形式：选取不超过该值的最大单位，整除时不带小数（如 "10 MB"、"1.5 GB"）
+func formatByteSize(bytes int64) string {
+	for _, unit := range byteSizeUnits {
+		if bytes < unit.size && unit.size > 1 {
+			continue
+		}
+		if bytes%unit.size == 0 {
+			return fmt.Sprintf("%d %s", bytes/unit.size, unit.name)
+		}
+		formatted := strconv.FormatFloat(float64(bytes)/float64(unit.size), 'f', 2, 64)
+		formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+		return formatted + " " + unit.name
+	}
+	return fmt.Sprintf("%d B", bytes)
+}
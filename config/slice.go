@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// 辅助函数：获取逗号分隔的字符串列表配置（如 tags = a, "b, c", d → [a, b, c, d] 中 "b, c" 保持为一个元素）
+// 按单行 CSV 解析，引号内的逗号不作为分隔符，结果中去掉引号；CSV 解析失败时退回简单的逗号拆分
+// 每个元素去除首尾空白，空元素被忽略；键不存在时返回默认值
+func getStringSliceConfig(section, key string, defaultValue []string) []string {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	fields, err := splitCSVLine(value)
+	if err != nil {
+		fields = strings.Split(value, ",")
+	}
+
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// 辅助函数：将配置值按一条完整的 CSV 记录解析（如 columns = id,name,"full, name" → [id name full, name]）
+// 与 getStringSliceConfig 不同，字段原样保留（包括空字段和引号内的空白），只忽略字段前的空白；
+// CSV 格式错误（如引号未闭合）时打印警告并返回默认值，不会退回简单的逗号拆分
+func getCSVConfig(section, key string, defaultValue []string) []string {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+	if strings.TrimSpace(value) == "" {
+		return []string{} // 空值是没有字段的记录（csv 会报 EOF）
+	}
+
+	fields, err := splitCSVLine(value)
+	if err != nil {
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	return fields
+}
+
+// 将单行内容按 CSV 规则拆分为字段
+func splitCSVLine(value string) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	return reader.Read()
+}
+
+// 辅助函数：获取逗号分隔的布尔值列表配置（如 stages_enabled = true,false,true）
+// 每个元素去除首尾空白后按 getBoolConfig 的规则解析；任一元素无法识别时打印警告并返回默认列表
+func getBoolSliceConfig(section, key string, defaultValue []bool) []bool {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	items := strings.Split(value, ",")
+	result := make([]bool, 0, len(items))
+	for _, item := range items {
+		boolVal, ok := parseBoolValue(item)
+		if !ok {
+			warnInvalidValue(section, key, value, fmt.Errorf("布尔值 %q 无法识别", strings.TrimSpace(item)))
+			return defaultValue
+		}
+		result = append(result, boolVal)
+	}
+	return result
+}
+
+// GetSlice 将 JSON 数组格式的配置值解码为类型化切片（如 weights = [1.0, 2.5, 3.0] → []float64）
+// 元素类型可以是任意可被 encoding/json 解码的类型；环境变量中的值按相同规则解码
+// 键不存在时返回默认值；值不是合法的 JSON 数组或元素类型不匹配时打印警告并返回默认值
+func GetSlice[T any](section, key string, defaultValue []T) []T {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+
+	var result []T
+	if err := json.Unmarshal([]byte(strings.TrimSpace(value)), &result); err != nil || result == nil {
+		if err == nil {
+			err = fmt.Errorf("必须是 JSON 数组")
+		}
+		warnInvalidValue(section, key, value, err)
+		return defaultValue
+	}
+	return result
+}
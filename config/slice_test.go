@@ -0,0 +1,34 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetStringSliceConfig(t *testing.T) {
+	loadTestConfig(t, `[list]
+quoted = a, "b, c", d
+empty = a,,b, ,
+blank =
+broken = a, "b, c
+`)
+
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"quoted", []string{"a", "b, c", "d"}},
+		{"empty", []string{"a", "b"}},
+		{"blank", []string{}},
+		{"broken", []string{"a", `"b`, "c"}}, // 引号未闭合时退回简单的逗号拆分
+	}
+	for _, tt := range tests {
+		if got := getStringSliceConfig("list", tt.key, nil); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("getStringSliceConfig(%s) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if got := getStringSliceConfig("list", "missing", []string{"x"}); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("键不存在时应返回默认值，实际 %q", got)
+	}
+}
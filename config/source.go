@@ -0,0 +1,201 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Source 配置值的来源
+type Source int
+
+const (
+	SourceNone     Source = iota // 未找到
+	SourceFlag                   // 命令行参数（见 RegisterFlags）
+	SourceEnv                    // 环境变量
+	SourceFile                   // 配置文件
+	SourceDefault                // 内嵌的默认配置或代码默认值（见 SetEmbeddedDefault、LoadDefaults）
+	SourceProvider               // RegisterProvider 注册的其他配置来源
+	SourceOverride               // 运行时覆盖层（见 PushOverrides）
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceFlag:
+		return "flag"
+	case SourceEnv:
+		return "env"
+	case SourceFile:
+		return "file"
+	case SourceDefault:
+		return "default"
+	case SourceProvider:
+		return "provider"
+	case SourceOverride:
+		return "override"
+	default:
+		return "none"
+	}
+}
+
+// LookupWithSource 按 GetConfig 的规则读取配置值，同时返回最终生效的来源，用于排查配置从哪里来
+// 键不存在时返回 "", SourceNone, false
+func LookupWithSource(section, key string) (string, Source, bool) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	value, exists := lookupLocked(section, key)
+	if !exists {
+		return "", SourceNone, false
+	}
+
+	// 与 lookupLocked 一致：新键不存在时值来自已废弃的旧键或别名的旧位置
+	if _, _, found := resolveRawLocked(section, key); !found {
+		if oldKey, renamed := renamedKeys[section][key]; renamed {
+			if _, _, found := resolveRawLocked(section, oldKey); found {
+				return value, sourceOfLocked(section, oldKey), true
+			}
+		}
+		if oldLoc, aliased := keyAliases[[2]string{section, key}]; aliased {
+			return value, sourceOfLocked(oldLoc[0], oldLoc[1]), true
+		}
+	}
+	return value, sourceOfLocked(section, key), true
+}
+
+// 判断单个键的原始值来自哪一层（调用方需持有 configMu 读锁）
+func sourceOfLocked(section, key string) Source {
+	value, source, exists := resolveRawLocked(section, key)
+	switch {
+	case !exists:
+		return SourceNone
+	case source == sourceOverride:
+		return SourceOverride
+	case source == sourceFlag:
+		return SourceFlag
+	case source == sourceEnv:
+		return SourceEnv
+	}
+
+	// 配置来源层：找到提供该值的节（可能是回退节），再区分配置文件、内嵌默认配置和其他来源
+	sections := sectionChainLocked(section)
+	if defaultSection != "" {
+		sections = append(sections, defaultSection)
+	}
+	for _, s := range sections {
+		if _, found := lookupProvidersLocked(s, key); !found {
+			continue
+		}
+		if fileValue, found := fileResult.sections[s][key]; found && fileValue == value {
+			return SourceFile
+		}
+		if defaults := defaultsLocked(); defaults != nil {
+			if defaultValue, found := defaults.sections[s][key]; found && defaultValue == value {
+				return SourceDefault
+			}
+		}
+		break
+	}
+	return SourceProvider
+}
+
+// PrecedenceReport 生成配置优先级报告：逐个列出每个已知键的环境变量值、配置文件值、默认值以及最终生效的来源
+// 已知键包括配置文件与内嵌默认配置中的键，以及通过 BindEnv 绑定的键（仅存在于约定 APP_ 环境变量中的键无法还原节名，不会列出）
+// 值按脱敏规则处理（见 MarkSectionSecret），可直接粘贴到问题报告中
+//
+//	[server] port
+//	  env     = 8080 (APP_SERVER_PORT)
+//	  file    = 50100
+//	  default = (未设置)
+//	  → env
+func PrecedenceReport() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	var sb strings.Builder
+	for _, k := range knownKeysLocked() {
+		section, key := k[0], k[1]
+		show := func(value string, exists bool) string {
+			if !exists {
+				return "(未设置)"
+			}
+			return redactValue(section, key, value)
+		}
+
+		fmt.Fprintf(&sb, "[%s] %s\n", section, key)
+		if envVar, value, exists := envValueLocked(section, key); exists {
+			fmt.Fprintf(&sb, "  env     = %s (%s)\n", show(value, true), envVar)
+		} else {
+			fmt.Fprintf(&sb, "  env     = %s\n", show("", false))
+		}
+		fileValue, inFile := fileResult.sections[section][key]
+		fmt.Fprintf(&sb, "  file    = %s\n", show(fileValue, inFile))
+		var defaultValue string
+		var inDefault bool
+		if defaults := defaultsLocked(); defaults != nil {
+			defaultValue, inDefault = defaults.sections[section][key]
+		}
+		fmt.Fprintf(&sb, "  default = %s\n", show(defaultValue, inDefault))
+		fmt.Fprintf(&sb, "  → %s\n", sourceOfLocked(section, key))
+	}
+	return sb.String()
+}
+
+// EnvOverriddenKeys 返回当前生效值来自环境变量（而不是配置文件或默认值）的配置项，按节名、键名排序，
+// 用于诊断页面展示运行中的配置与提交的配置文件有哪些差异；扫描范围同 PrecedenceReport 的已知键
+func EnvOverriddenKeys() [][2]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	var keys [][2]string
+	for _, k := range knownKeysLocked() {
+		if sourceOfLocked(k[0], k[1]) == SourceEnv {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// 已知键：配置中已加载的键与 BindEnv 绑定的键，按节名、键名排序（调用方需持有 configMu 读锁）
+func knownKeysLocked() [][2]string {
+	known := make(map[[2]string]bool)
+	for section, values := range config {
+		for key := range values {
+			known[[2]string{section, key}] = true
+		}
+	}
+	for k := range envBindings {
+		known[k] = true
+	}
+
+	keys := make([][2]string, 0, len(known))
+	for k := range known {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+// 查找配置项实际命中的环境变量名及其值（规则同 lookupEnvLocked，调用方需持有 configMu 读锁）
+func envValueLocked(section, key string) (string, string, bool) {
+	if _, exists := lookupEnvLocked(section, key); !exists {
+		return "", "", false
+	}
+
+	candidates := []string{envBindings[[2]string{section, key}], envKeyFor(section, key), exactEnvKeyFor(section, key)}
+	for _, envVar := range candidates {
+		if envVar == "" {
+			continue
+		}
+		if value, exists := os.LookupEnv(envVar); exists {
+			return envVar, value, true
+		}
+	}
+	return "", "", false
+}
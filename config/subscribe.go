@@ -0,0 +1,49 @@
+package config
+
+import "sync"
+
+// 配置变更订阅者：只读端 → 可写端
+var (
+	subscribersMu sync.Mutex
+	subscribers   = make(map[<-chan struct{}]chan struct{})
+)
+
+// Subscribe 返回一个在每次成功重新加载配置（Load/Reload/LoadFromURL）后收到信号的通道
+// 通道带 1 个缓冲：消费者来不及处理时多次变更合并为一次信号，不会阻塞重新加载
+// 不再需要时调用 Unsubscribe 释放
+// 通道只传递"已变更"信号（为兼容已有调用方，有意保持为 struct{}）；由于合并信号会丢失中间状态，
+// 需要知道具体哪些键变化时请使用 OnChange，它按顺序逐个送达每次变更的 ConfigDelta
+func Subscribe() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+	subscribers[ch] = ch
+	return ch
+}
+
+// Unsubscribe 取消订阅并关闭通道；重复调用或传入未订阅的通道时忽略
+func Unsubscribe(ch <-chan struct{}) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+
+	if sendCh, exists := subscribers[ch]; exists {
+		delete(subscribers, ch)
+		close(sendCh)
+	}
+}
+
+// 通知所有订阅者配置已变更（非阻塞发送），并将 previous → 当前配置的差异分发给 OnChange 回调（调用方需持有 configMu）
+func notifySubscribers(previous map[string]map[string]string) {
+	notifyChangeListeners(diffSections(previous, config))
+
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+
+	for _, ch := range subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
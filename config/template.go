@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// 已解析模板缓存：key 为原始配置值，避免每次 GetConfig 都重新解析
+var (
+	templateMu    sync.Mutex
+	templateCache = make(map[string]*template.Template)
+)
+
+// 判断配置值是否为模板（包含 {{ }}）
+func isTemplateValue(value string) bool {
+	start := strings.Index(value, "{{")
+	return start >= 0 && strings.Contains(value[start:], "}}")
+}
+
+// 渲染模板配置值：非模板值原样返回；解析或执行失败时打印警告并返回原始值
+// 模板可访问的数据：
+//   - .env.<NAME>        环境变量
+//   - .<section>.<key>   配置文件中的原始值（不会递归渲染，避免循环引用）
+//
+// 示例：greeting = Hello {{ .env.USER }}
+func renderValue(value string) string {
+	if !isTemplateValue(value) {
+		return value
+	}
+
+	tmpl, err := getTemplate(value)
+	if err != nil {
+		fmt.Printf("警告：配置模板解析失败，使用原始值 %q: %v\n", value, err)
+		return value
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, templateData()); err != nil {
+		fmt.Printf("警告：配置模板渲染失败，使用原始值 %q: %v\n", value, err)
+		return value
+	}
+	return sb.String()
+}
+
+// 获取（或解析并缓存）模板
+func getTemplate(value string) (*template.Template, error) {
+	templateMu.Lock()
+	defer templateMu.Unlock()
+
+	if tmpl, ok := templateCache[value]; ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := template.New("config").Option("missingkey=zero").Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	templateCache[value] = tmpl
+	return tmpl, nil
+}
+
+// 构建模板数据：各节的原始配置值 + 环境变量（env 优先于同名节，调用方需持有 configMu）
+func templateData() map[string]interface{} {
+	data := make(map[string]interface{}, len(config)+1)
+	for section, values := range config {
+		if !envOnlySections[section] {
+			data[section] = values
+		}
+	}
+
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if name, value, ok := strings.Cut(kv, "="); ok {
+			env[name] = value
+		}
+	}
+	data["env"] = env
+	return data
+}
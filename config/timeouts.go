@@ -0,0 +1,38 @@
+package config
+
+import "time"
+
+// ServerTimeouts 常见的 HTTP 服务超时配置，字段与 http.Server 对应（ShutdownTimeout 用于 Shutdown 的 context）
+type ServerTimeouts struct {
+	ReadTimeout     time.Duration // 读取整个请求（含请求体）的超时
+	WriteTimeout    time.Duration // 写响应的超时
+	IdleTimeout     time.Duration // keep-alive 连接的空闲超时
+	ShutdownTimeout time.Duration // 优雅退出时等待进行中请求完成的时间
+}
+
+// 服务超时配置的默认值
+const (
+	defaultReadTimeout     = 15 * time.Second
+	defaultWriteTimeout    = 15 * time.Second
+	defaultIdleTimeout     = 60 * time.Second
+	defaultShutdownTimeout = 30 * time.Second
+)
+
+// GetServerTimeouts 从指定节读取服务超时配置，读取的键名及默认值（均为时长，如 500ms、2s）：
+//   - read_timeout      默认 15s
+//   - write_timeout     默认 15s
+//   - idle_timeout      默认 60s
+//   - shutdown_timeout  默认 30s
+//
+// 各键按 GetConfig 的优先级解析（可被环境变量覆盖），值无效或为负数时打印警告并使用默认值
+//
+//	t := config.GetServerTimeouts("server")
+//	srv := &http.Server{ReadTimeout: t.ReadTimeout, WriteTimeout: t.WriteTimeout, IdleTimeout: t.IdleTimeout}
+func GetServerTimeouts(section string) ServerTimeouts {
+	return ServerTimeouts{
+		ReadTimeout:     getDurationConfig(section, "read_timeout", defaultReadTimeout),
+		WriteTimeout:    getDurationConfig(section, "write_timeout", defaultWriteTimeout),
+		IdleTimeout:     getDurationConfig(section, "idle_timeout", defaultIdleTimeout),
+		ShutdownTimeout: getDurationConfig(section, "shutdown_timeout", defaultShutdownTimeout),
+	}
+}
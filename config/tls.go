@@ -0,0 +1,52 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// 配置中可用的 TLS 最低版本
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// 未配置 min_version 时的 TLS 最低版本
+const defaultTLSMinVersion = "1.2"
+
+// GetTLSConfig 从指定节读取证书配置并返回可直接使用的 *tls.Config，读取的键名：
+//   - cert_file    证书文件路径（PEM，必填，规则同 getPathConfig，支持 ~ 展开）
+//   - key_file     私钥文件路径（PEM，必填）
+//   - min_version  TLS 最低版本：1.0、1.1、1.2、1.3（可带 TLS 前缀，如 TLS1.3），默认 1.2
+//
+// 缺少证书路径、文件无法读取或不匹配、min_version 无效时返回带配置项名称的错误
+//
+//	[server.tls]
+//	cert_file = /etc/login/tls.crt
+//	key_file = /etc/login/tls.key
+//	min_version = 1.3
+func GetTLSConfig(section string) (*tls.Config, error) {
+	certFile := getPathConfig(section, "cert_file", "")
+	keyFile := getPathConfig(section, "key_file", "")
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("配置节 [%s] 必须设置 cert_file 和 key_file", section)
+	}
+
+	versionText := getStringConfig(section, "min_version", defaultTLSMinVersion)
+	minVersion, ok := tlsVersions[strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(versionText)), "TLS")]
+	if !ok {
+		return nil, fmt.Errorf("配置项 [%s] min_version 的值 %q 无效，应为 1.0、1.1、1.2 或 1.3", section, versionText)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("配置节 [%s] 的证书 %s / 私钥 %s 加载失败: %w", section, certFile, keyFile, err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   minVersion,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetTyped 读取配置并推断其原生类型，便于通用工具按原生 JSON 类型输出配置；键不存在时返回 nil
+// 推断规则见 inferValue；环境变量和配置文件中的值使用相同规则
+func GetTyped(section, key string) interface{} {
+	value, exists := lookup(section, key)
+	if !exists {
+		return nil
+	}
+	return inferValue(value)
+}
+
+// GetSectionGeneric 返回指定节的全部配置，值按 GetTyped 的规则推断为 bool/int/float64/string，
+// 得到类似 JSON 对象的结构，便于传给模板或通用序列化；节不存在时返回空 map
+// 键集合取自已加载的配置，值按 GetConfig 的优先级解析（已包含环境变量覆盖）；返回的是副本
+func GetSectionGeneric(section string) map[string]interface{} {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	result := make(map[string]interface{}, len(config[section]))
+	for key := range config[section] {
+		if value, exists := lookupLocked(section, key); exists {
+			result[key] = inferValue(value)
+		}
+	}
+	return result
+}
+
+// GetOr 按 T 的类型选择对应的解析规则读取配置，键不存在或解析失败时返回 defaultValue：
+//   - string         同 getStringConfig
+//   - int            同 getIntConfig（允许千位分隔符）
+//   - int64          十进制整数（允许千位分隔符）
+//   - float64        同 getFloatConfig（拒绝 NaN、Inf）
+//   - bool           同 getBoolConfig（true/false、1/0、yes/no、on/off）
+//   - time.Duration  同 getDurationConfig（拒绝负数）
+//
+// 其他类型一律返回 defaultValue；示例：port := config.GetOr("app", "port", 50100)
+func GetOr[T any](section, key string, defaultValue T) T {
+	var result interface{}
+	switch def := any(defaultValue).(type) {
+	case string:
+		result = getStringConfig(section, key, def)
+	case int:
+		result = getIntConfig(section, key, def)
+	case int64:
+		result = getInt64Config(section, key, def)
+	case float64:
+		result = getFloatConfig(section, key, def)
+	case bool:
+		result = getBoolConfig(section, key, def)
+	case time.Duration:
+		result = getDurationConfig(section, key, def)
+	default:
+		return defaultValue
+	}
+	return result.(T)
+}
+
+// 辅助函数：获取 int64 类型配置（允许千位分隔符），键不存在或解析失败时返回默认值
+func getInt64Config(section, key string, defaultValue int64) int64 {
+	value, exists := lookup(section, key)
+	if !exists {
+		return defaultValue
+	}
+	n, err := strconv.ParseInt(stripThousandsSeparators(strings.TrimSpace(value)), 10, 64)
+	if err != nil {
+		reportTypeMismatch(section, key, value, fmt.Errorf("应为整数"))
+		return defaultValue
+	}
+	return n
+}
+
+// 推断配置值的类型（按顺序匹配）：
+//  1. true/false（忽略大小写）→ bool；注意 yes/no/on/off 和 1/0 不会被推断为布尔值
+//  2. 十进制整数（如 42、-7）→ int；因此 "0"/"1" 推断为 int 而不是 bool
+//  3. 浮点数（如 3.14、1e3）→ float64；inf/nan 等特殊写法仍视为字符串
+//  4. 其他 → string（如 10,000 这类带分隔符的数字）
+//
+// 注意：带前导零的编号（如 007）会按规则 2 推断为整数 7，需要保留原样时请用 GetConfig
+func inferValue(value string) interface{} {
+	trimmed := strings.TrimSpace(value)
+
+	switch strings.ToLower(trimmed) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if n, err := strconv.Atoi(trimmed); err == nil {
+		return n
+	}
+
+	if f, err := strconv.ParseFloat(trimmed, 64); err == nil && isPlainFloat(trimmed) {
+		return f
+	}
+	return value
+}
+
+// 判断是否为普通的十进制浮点数写法（排除 inf、nan、十六进制浮点等）
+func isPlainFloat(s string) bool {
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9', c == '.', c == '-', c == '+', c == 'e', c == 'E':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+// 清空已记录的初始化错误，测试结束后恢复宽松类型模式
+func resetInitErrors(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		initErrMu.Lock()
+		initErrors = nil
+		invalidValueKeys = make(map[[2]string]bool)
+		initErrMu.Unlock()
+	}
+	reset()
+	t.Cleanup(func() {
+		SetStrictTypes(false)
+		reset()
+	})
+}
+
+func TestBadIntLenient(t *testing.T) {
+	resetInitErrors(t)
+	loadTestConfig(t, "[app]\nport = abc\nlimit = 10x\n")
+
+	if got := getIntConfig("app", "port", 50100); got != 50100 {
+		t.Errorf("getIntConfig = %d, want 50100", got)
+	}
+	if got := getInt64Config("app", "limit", 7); got != 7 {
+		t.Errorf("getInt64Config = %d, want 7", got)
+	}
+	if err := InitError(); err != nil {
+		t.Errorf("宽松模式下不应记录错误: %v", err)
+	}
+}
+
+func TestBadIntStrict(t *testing.T) {
+	resetInitErrors(t)
+	SetStrictTypes(true)
+	loadTestConfig(t, "[app]\nport = abc\nlimit = 10x\n")
+
+	for i := 0; i < 3; i++ {
+		if got := getIntConfig("app", "port", 50100); got != 50100 {
+			t.Errorf("getIntConfig = %d, want 50100", got)
+		}
+		if got := GetOr[int64]("app", "limit", 7); got != 7 {
+			t.Errorf("GetOr[int64] = %d, want 7", got)
+		}
+	}
+
+	err := InitError()
+	if err == nil {
+		t.Fatal("严格模式下应记录无效值错误")
+	}
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) || len(joined.Unwrap()) != 2 {
+		t.Errorf("每个配置项应只记录一次错误，实际: %v", err)
+	}
+}
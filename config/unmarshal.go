@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Unmarshal 将指定节的配置填充到结构体指针 v 中，按与 GetConfig 相同的优先级解析每个键
+// 字段标签：
+//   - config:"port"            指定键名（未设置时使用小写字段名，config:"-" 跳过该字段）
+//   - config:"section:server"  结构体（或结构体指针）字段整体从 [server] 节填充，可以多层嵌套；nil 指针会自动分配
+//   - default:"50100"          键不存在时使用的默认值，按字段类型解析
+//
+// 键不存在且没有 default 标签时保留字段原值
+// 支持的字段类型：string、bool、整数、无符号整数、浮点数、time.Duration、[]string（逗号分隔）
+//
+// 示例（一次填充整个应用配置时 section 参数传顶层结构体自身的键所在的节，没有时可传 ""）：
+//
+//	type AppConfig struct {
+//	    Name   string        `config:"name" default:"did-new"`
+//	    Port   int           `config:"port" default:"50100"`
+//	    Server *ServerConfig `config:"section:server"`
+//	}
+func Unmarshal(section string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("Unmarshal 需要非空的结构体指针")
+	}
+	return unmarshalStruct(section, rv.Elem())
+}
+
+// 填充结构体的各个字段，section:xxx 标签的字段递归填充对应的节
+func unmarshalStruct(section string, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !rv.Field(i).CanSet() {
+			continue
+		}
+
+		key := field.Tag.Get("config")
+		if key == "-" {
+			continue
+		}
+		if sub, ok := strings.CutPrefix(key, "section:"); ok {
+			if err := unmarshalSection(sub, rv.Field(i)); err != nil {
+				return fmt.Errorf("字段 %s: %w", field.Name, err)
+			}
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+
+		raw, exists := lookup(section, key)
+		if !exists {
+			if raw, exists = field.Tag.Lookup("default"); !exists {
+				continue
+			}
+		}
+
+		if err := setFieldValue(rv.Field(i), raw); err != nil {
+			return fmt.Errorf("配置项 [%s] %s 无法解析到字段 %s: %w", section, key, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// 将节填充到结构体或结构体指针字段（nil 指针先分配）
+func unmarshalSection(section string, field reflect.Value) error {
+	if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Struct {
+		return fmt.Errorf("section 标签只能用于结构体或结构体指针字段，实际为 %s", field.Type())
+	}
+	return unmarshalStruct(section, field)
+}
+
+// 按字段类型解析字符串并赋值
+func setFieldValue(field reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, ok := parseBoolValue(raw)
+		if !ok {
+			return fmt.Errorf("无法识别的布尔值 %q", raw)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(stripThousandsSeparators(raw), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(stripThousandsSeparators(raw), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("不支持的字段类型 %s", field.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items).Convert(field.Type()))
+	default:
+		return fmt.Errorf("不支持的字段类型 %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 已注册的校验器（按注册顺序执行）
+var (
+	validatorMu sync.Mutex
+	validators  []keyValidator
+)
+
+type keyValidator struct {
+	section string
+	key     string
+	fn      func(string) error
+}
+
+// ValidationErrors 汇总一次校验中的所有失败项，Load/Reload 校验失败时返回该类型
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("配置校验失败（%d 项）: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// RegisterValidator 为指定配置项注册自定义校验函数（如校验路径存在、正则可编译）
+// 校验器在 Load/Reload 时针对解析后的最终值执行，也可通过 Validate 手动触发
+// 仅对环境变量或配置文件中存在的键执行，缺失的键不校验
+// 校验函数执行时持有包内配置锁，不应回调本包的读取函数
+func RegisterValidator(section, key string, fn func(string) error) {
+	validatorMu.Lock()
+	defer validatorMu.Unlock()
+	validators = append(validators, keyValidator{section: section, key: key, fn: fn})
+}
+
+// Validate 对当前配置运行所有已注册的校验器，返回全部校验错误（无错误时返回 nil）
+// 启动时可据此决定是否中止：if errs := config.Validate(); len(errs) > 0 { ... }
+func Validate() []error {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return validateLocked()
+}
+
+// 运行所有校验器（调用方需持有 configMu）
+func validateLocked() []error {
+	validatorMu.Lock()
+	registered := append([]keyValidator(nil), validators...)
+	validatorMu.Unlock()
+
+	var errs []error
+	for _, v := range registered {
+		value, exists := lookupLocked(v.section, v.key)
+		if !exists {
+			continue
+		}
+		if err := v.fn(value); err != nil {
+			errs = append(errs, fmt.Errorf("配置项 [%s] %s 校验失败: %w", v.section, v.key, err))
+		}
+	}
+	return errs
+}
+
+// 允许出现在配置文件中的节（nil 表示不限制，读写均需持有 configMu）
+var allowedSections map[string]bool
+
+// SetAllowedSections 开启严格模式：之后 Load/Reload 的配置文件中出现不在 names 中的节时返回错误（列出全部意外的节），
+// 当前配置保持不变；用于发现 [sever] 这类拼错的节名（否则其中的配置会被静默忽略）
+// [meta] 节（见 extends）总是允许；节名遵循 SetCaseSensitive 的大小写规则；传入 nil 关闭该检查
+// 注意：包初始化时的自动加载早于调用方设置允许列表，不受此限制，需要时在设置后调用一次 Reload
+func SetAllowedSections(names []string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if names == nil {
+		allowedSections = nil
+		return
+	}
+	allowedSections = make(map[string]bool, len(names))
+	for _, name := range names {
+		allowedSections[name] = true
+	}
+}
+
+// 检查解析结果中是否有不在允许列表中的节（调用方需持有 configMu）
+func checkAllowedSectionsLocked(result *parseResult) error {
+	if allowedSections == nil {
+		return nil
+	}
+
+	var unexpected []string
+	for section := range result.sections {
+		if section == metaSection || isAllowedSectionLocked(section) {
+			continue
+		}
+		unexpected = append(unexpected, "["+section+"]")
+	}
+	if len(unexpected) == 0 {
+		return nil
+	}
+	sort.Strings(unexpected)
+	return fmt.Errorf("配置中包含未允许的节: %s", strings.Join(unexpected, "、"))
+}
+
+func isAllowedSectionLocked(section string) bool {
+	if allowedSections[section] {
+		return true
+	}
+	if caseSensitiveFor(section) {
+		return false
+	}
+	for name := range allowedSections {
+		if strings.EqualFold(name, section) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// 后台重新加载失败时的处理函数（见 SetReloadErrorHandler）
+var (
+	reloadErrorMu      sync.Mutex
+	reloadErrorHandler func(error)
+)
+
+// SetReloadErrorHandler 设置后台重新加载（如 PollConfig）失败时的处理函数
+// 失败时上一次可用的配置保持生效，错误通过该函数上报；传入 nil 取消
+func SetReloadErrorHandler(handler func(error)) {
+	reloadErrorMu.Lock()
+	defer reloadErrorMu.Unlock()
+	reloadErrorHandler = handler
+}
+
+// 后台重新加载：Reload 失败时当前配置保持不变，错误交给 SetReloadErrorHandler 设置的处理函数
+func backgroundReload() error {
+	err := Reload()
+	if err == nil {
+		return nil
+	}
+
+	reloadErrorMu.Lock()
+	handler := reloadErrorHandler
+	reloadErrorMu.Unlock()
+	if handler != nil {
+		handler(err)
+	}
+	return err
+}
+
+// PollConfig 在后台按固定间隔检查配置文件修改时间，发生变化时调用 Reload
+// 适用于 fsnotify 不可靠的网络文件系统或容器环境；ctx 取消后停止轮询
+// 每次重新加载后调用 onChange（参数为 Reload 的返回值，可为 nil）
+// 重新加载失败时保留上一次可用的配置，并额外调用 SetReloadErrorHandler 设置的处理函数
+func PollConfig(ctx context.Context, interval time.Duration, onChange func(error)) {
+	lastModTime, _ := ConfigFileModTime()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			modTime, err := ConfigFileModTime()
+			if err != nil || modTime.Equal(lastModTime) {
+				// 文件暂时不可访问（如正在被替换）时等待下一轮
+				continue
+			}
+			lastModTime = modTime
+
+			err = backgroundReload()
+			if onChange != nil {
+				onChange(err)
+			}
+		}
+	}()
+}
+
+// ReloadOnSignal 收到指定信号（默认 SIGHUP）时重新加载配置，符合 Unix 守护进程的惯例
+// 每次重新加载的结果都会打印日志；失败时保留之前的配置，并调用 SetReloadErrorHandler 设置的处理函数
+// 返回的函数用于停止监听信号，可重复调用
+func ReloadOnSignal(sig ...os.Signal) (stop func()) {
+	if len(sig) == 0 {
+		sig = []os.Signal{syscall.SIGHUP}
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, sig...)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case s := <-signals:
+				if err := backgroundReload(); err != nil {
+					currentLogger().Warn("收到信号，配置重新加载失败，继续使用之前的配置", "signal", s, "error", err)
+					continue
+				}
+				currentLogger().Info("收到信号，配置已重新加载", "signal", s, "path", ConfigFilePath())
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPollConfigCorruptFileKeepsConfig(t *testing.T) {
+	path := loadTestConfig(t, "[app]\nport = 1\n")
+
+	handled := make(chan error, 1)
+	SetReloadErrorHandler(func(err error) {
+		select {
+		case handled <- err:
+		default:
+		}
+	})
+	defer SetReloadErrorHandler(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	PollConfig(ctx, 10*time.Millisecond, nil)
+
+	if err := os.WriteFile(path, []byte(malformedConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour) // 确保修改时间与加载时不同
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-handled:
+		if err == nil {
+			t.Error("处理函数收到的错误为 nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SetReloadErrorHandler 设置的处理函数未被调用")
+	}
+	cancel()
+
+	if got := mustLookup(t, "app", "port"); got != "1" {
+		t.Errorf("port = %q，期望保持 1", got)
+	}
+}
+
+func TestBackgroundReloadSuccessSkipsHandler(t *testing.T) {
+	path := loadTestConfig(t, "[app]\nport = 1\n")
+
+	called := false
+	SetReloadErrorHandler(func(error) { called = true })
+	defer SetReloadErrorHandler(nil)
+
+	if err := os.WriteFile(path, []byte("[app]\nport = 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := backgroundReload(); err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Error("重载成功时不应调用错误处理函数")
+	}
+	if got := mustLookup(t, "app", "port"); got != "2" {
+		t.Errorf("port = %q，期望 2", got)
+	}
+}